@@ -7,6 +7,9 @@ type Allocation struct {
 	Port     int     `json:"port"`
 	Notes    *string `json:"notes"`
 	Assigned bool    `json:"assigned"`
+	// IsDefault is only returned by the client API and marks the server's
+	// primary allocation.
+	IsDefault bool `json:"is_default"`
 }
 
 type AllocationNoteOptions struct {
@@ -1,37 +1,254 @@
 package api
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 type Egg struct {
-	ID           int               `json:"id"`
-	UUID         string            `json:"uuid"`
-	NestID       int               `json:"nest_id"`
-	Author       string            `json:"author"`
-	Description  string            `json:"description"`
+	ID          int    `json:"id"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	NestID      int    `json:"nest_id"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+	// DockerImage is the first of DockerImages, kept by the panel for older
+	// clients.
+	DockerImage  string            `json:"docker_image"`
 	DockerImages map[string]string `json:"docker_images"`
-	Config       any               `json:"config"`
-	Startup      any               `json:"startup"`
-	Script       any               `json:"script"`
+	Config       EggConfig         `json:"config"`
+	Startup      string            `json:"startup"`
+	Script       EggScript         `json:"script"`
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
+
+	// Features and UpdateURL are only set for eggs read from an export file
+	// (see package ptdl); the application API does not return them.
+	Features  []string `json:"features,omitempty"`
+	UpdateURL string   `json:"update_url,omitempty"`
+
+	Relationships *EggRelationships `json:"relationships,omitempty"`
+
+	// The fields below are flattened from Relationships when the matching
+	// EggInclude was requested. ConfigDetails and ScriptDetails hold the
+	// configuration and install script after inheritance from the eggs
+	// they are copied from has been applied. Variables is only set when
+	// EggIncludeVariables was requested.
+	Nest          *Nest          `json:"-"`
+	Servers       []*Server      `json:"-"`
+	ConfigDetails *EggConfig     `json:"-"`
+	ScriptDetails *EggScript     `json:"-"`
+	Variables     []*EggVariable `json:"-"`
+}
+
+// EggRelationships holds the relationships requested with EggInclude values.
+type EggRelationships struct {
+	Nest      *ListItem[Nest]                 `json:"nest,omitempty"`
+	Servers   *PaginatedResponse[Server]      `json:"servers,omitempty"`
+	Config    *ListItem[EggConfig]            `json:"config,omitempty"`
+	Script    *ListItem[EggScript]            `json:"script,omitempty"`
+	Variables *PaginatedResponse[EggVariable] `json:"variables,omitempty"`
+}
+
+// UnmarshalJSON decodes an egg and flattens its included relationships.
+func (e *Egg) UnmarshalJSON(data []byte) error {
+	type plain Egg
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	if r := e.Relationships; r != nil {
+		e.Nest = itemAttributes(r.Nest)
+		e.Servers = listAttributes(r.Servers)
+		e.ConfigDetails = itemAttributes(r.Config)
+		e.ScriptDetails = itemAttributes(r.Script)
+		e.Variables = listAttributes(r.Variables)
+	}
+	return nil
 }
 
+// RequiredVariables returns the variables whose rules mark them as required.
+// Variables must have been included with EggIncludeVariables.
+func (e *Egg) RequiredVariables() []*EggVariable {
+	var out []*EggVariable
+	for _, v := range e.Variables {
+		if v.Required() {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// DefaultEnvironment returns the default value of every variable keyed by
+// its environment variable name, ready to be adjusted and passed as
+// ServerCreateOptions.Environment. Variables must have been included with
+// EggIncludeVariables.
+func (e *Egg) DefaultEnvironment() map[string]string {
+	env := make(map[string]string, len(e.Variables))
+	for _, v := range e.Variables {
+		env[v.EnvVariable] = v.DefaultValue
+	}
+	return env
+}
+
+// EggConfig tells Wings how to configure and watch a server's process.
+//
+// The panel stores files, startup and logs as JSON-encoded strings and
+// returns them decoded, except for older panels and exported eggs which
+// return the strings as-is. Both forms are accepted; values that are not
+// JSON, such as a startup command in place of the startup detection, are
+// left empty.
 type EggConfig struct {
-	Files   any    `json:"files"`
-	Startup any    `json:"startup"`
-	Stop    string `json:"stop"`
-	Logs    any    `json:"logs"`
+	Files        map[string]EggConfigFile `json:"files"`
+	Startup      EggStartup               `json:"startup"`
+	Stop         string                   `json:"stop"`
+	Logs         EggLogs                  `json:"logs"`
+	FileDenylist []string                 `json:"file_denylist,omitempty"`
+	// Extends is the ID of the egg this configuration is copied from.
+	Extends *int `json:"extends,omitempty"`
 }
 
+func (c *EggConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Files        json.RawMessage `json:"files"`
+		Startup      json.RawMessage `json:"startup"`
+		Stop         string          `json:"stop"`
+		Logs         json.RawMessage `json:"logs"`
+		FileDenylist []string        `json:"file_denylist"`
+		Extends      *int            `json:"extends"`
+	}
+	if err := unmarshalEmbedded(data, &raw); err != nil {
+		return err
+	}
+	*c = EggConfig{Stop: raw.Stop, FileDenylist: raw.FileDenylist, Extends: raw.Extends}
+	if err := unmarshalEmbedded(raw.Files, &c.Files); err != nil {
+		return fmt.Errorf("failed to decode egg config files: %w", err)
+	}
+	if err := unmarshalEmbedded(raw.Startup, &c.Startup); err != nil {
+		return fmt.Errorf("failed to decode egg config startup: %w", err)
+	}
+	if err := unmarshalEmbedded(raw.Logs, &c.Logs); err != nil {
+		return fmt.Errorf("failed to decode egg config logs: %w", err)
+	}
+	return nil
+}
+
+// EggConfigFile describes how Wings rewrites a configuration file before
+// the server starts. Find maps keys (or paths, depending on Parser) to the
+// values to set, which may contain {{server.build...}} placeholders.
+type EggConfigFile struct {
+	Parser string         `json:"parser"`
+	Find   map[string]any `json:"find"`
+}
+
+func (f *EggConfigFile) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Parser string          `json:"parser"`
+		Find   json.RawMessage `json:"find"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = EggConfigFile{Parser: raw.Parser}
+	return unmarshalEmbedded(raw.Find, &f.Find)
+}
+
+// EggStartup tells Wings when a server has finished starting. Done holds the
+// console lines that mark the server as running; the panel accepts either a
+// single string or a list.
 type EggStartup struct {
-	Done            string   `json:"done"`
-	UserInteraction []string `json:"user_interaction"`
+	Done            []string `json:"done"`
+	UserInteraction []string `json:"user_interaction,omitempty"`
+}
+
+func (s *EggStartup) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Done            json.RawMessage `json:"done"`
+		UserInteraction json.RawMessage `json:"user_interaction"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = EggStartup{}
+	if err := unmarshalStrings(raw.Done, &s.Done); err != nil {
+		return fmt.Errorf("failed to decode done: %w", err)
+	}
+	if err := unmarshalStrings(raw.UserInteraction, &s.UserInteraction); err != nil {
+		return fmt.Errorf("failed to decode user_interaction: %w", err)
+	}
+	return nil
+}
+
+// MarshalJSON writes a single Done line as a string, the form understood by
+// every panel and Wings version.
+func (s EggStartup) MarshalJSON() ([]byte, error) {
+	type plain EggStartup
+	if len(s.Done) != 1 {
+		return json.Marshal(plain(s))
+	}
+	return json.Marshal(struct {
+		Done            string   `json:"done"`
+		UserInteraction []string `json:"user_interaction,omitempty"`
+	}{s.Done[0], s.UserInteraction})
 }
 
+// EggLogs is the legacy log configuration. Wings ignores it, but the panel
+// still stores and returns it.
+type EggLogs struct {
+	Custom   bool   `json:"custom,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+// EggScript is the installation script run in a separate container when a
+// server is installed or reinstalled.
 type EggScript struct {
 	Privileged bool   `json:"privileged"`
 	Install    string `json:"install"`
 	Entry      string `json:"entry"`
 	Container  string `json:"container"`
-	Extends    any    `json:"extends"`
+	// Extends is the ID of the egg this script is copied from.
+	Extends *int `json:"extends,omitempty"`
+}
+
+// unmarshalEmbedded decodes data into v, decoding it a second time if it is a
+// JSON-encoded string. Null, empty strings, strings that are not JSON and
+// PHP's empty array (the panel's encoding of an empty object) leave v
+// untouched.
+func unmarshalEmbedded(data []byte, v any) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = bytes.TrimSpace([]byte(s))
+		if !json.Valid(data) {
+			return nil
+		}
+	}
+	switch string(data) {
+	case "", "null", "[]":
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// unmarshalStrings decodes either a string or a list of strings.
+func unmarshalStrings(data []byte, v *[]string) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s != "" {
+			*v = []string{s}
+		}
+		return nil
+	}
+	return json.Unmarshal(data, v)
 }
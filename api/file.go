@@ -1,6 +1,13 @@
 package api
 
-import "time"
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"time"
+)
 
 type FileObject struct {
 	Name       string    `json:"name"`
@@ -14,6 +21,33 @@ type FileObject struct {
 	ModifiedAt time.Time `json:"modified_at"`
 }
 
+// FileInfo describes the file as an fs.FileInfo. The permissions come from
+// ModeBits; directories get fs.ModeDir and symlinks fs.ModeSymlink, without
+// fs.ModeDir even if they point to a directory. Sys returns f.
+func (f *FileObject) FileInfo() fs.FileInfo {
+	return fileInfo{f}
+}
+
+type fileInfo struct{ f *FileObject }
+
+func (i fileInfo) Name() string       { return i.f.Name }
+func (i fileInfo) Size() int64        { return i.f.Size }
+func (i fileInfo) ModTime() time.Time { return i.f.ModifiedAt }
+func (i fileInfo) IsDir() bool        { return i.Mode().IsDir() }
+func (i fileInfo) Sys() any           { return i.f }
+
+func (i fileInfo) Mode() fs.FileMode {
+	perm, _ := strconv.ParseUint(i.f.ModeBits, 8, 32)
+	mode := fs.FileMode(perm) & fs.ModePerm
+	switch {
+	case i.f.IsSymlink:
+		mode |= fs.ModeSymlink
+	case !i.f.IsFile:
+		mode |= fs.ModeDir
+	}
+	return mode
+}
+
 // SignedURL represents a response containing a temporary, signed URL.
 type SignedURL struct {
 	URL string `json:"url"`
@@ -87,3 +121,221 @@ type FileListResponse struct {
 	Object string                `json:"object"`
 	Data   []*FileObjectResponse `json:"data"`
 }
+
+// DefaultDownloadResumes is the number of times DownloadTo resumes an
+// interrupted transfer unless DownloadOptions.MaxResumes says otherwise.
+const DefaultDownloadResumes = 3
+
+// DownloadOptions configures FileService.DownloadTo.
+type DownloadOptions struct {
+	// Offset is the number of bytes already downloaded, e.g. by an earlier
+	// run writing to the same file. The download starts there.
+	Offset int64
+	// MaxResumes is the number of times an interrupted transfer is resumed
+	// from where it stopped. Zero means DefaultDownloadResumes; a negative
+	// value disables resuming.
+	MaxResumes int
+	// Progress, if set, is called after every chunk written with the number
+	// of bytes downloaded so far (including Offset) and the size of the
+	// file, or -1 if Wings did not report it.
+	Progress func(downloaded, total int64)
+}
+
+// UploadFile is a file uploaded by FileService.UploadFiles.
+type UploadFile struct {
+	// Name is the name of the file in the target directory.
+	Name string
+	// Content is streamed to Wings without being buffered. If it implements
+	// io.Seeker, it is rewound to retry an upload rejected because its
+	// signed URL expired.
+	Content io.Reader
+	// Size is the size of Content in bytes. If zero, it is taken from
+	// Content's Len method or by seeking to its end, and is otherwise
+	// unknown.
+	Size int64
+}
+
+// UploadOptions configures FileService.UploadFiles.
+type UploadOptions struct {
+	// MaxSize is the largest file accepted. The client API cannot see the
+	// node's upload limit, so the caller supplies it, for example from
+	// Node.UploadLimit through the application API. Files known to be
+	// larger are rejected before anything is uploaded, and files of unknown
+	// size are cut off once they exceed it. Zero disables the check and
+	// leaves the limit to Wings, which fails the upload mid-transfer.
+	MaxSize int64
+	// Progress, if set, is called after every chunk sent with the name of
+	// the file, the number of bytes sent so far and its size, or -1 if the
+	// size is unknown.
+	Progress func(name string, uploaded, total int64)
+}
+
+// UploadTooLargeError is returned by FileService.UploadFiles for a file
+// larger than UploadOptions.MaxSize.
+type UploadTooLargeError struct {
+	Name string
+	// Size is the size of the file, or the number of bytes read before the
+	// upload was cut off if it was not known up front.
+	Size  int64
+	Limit int64
+}
+
+func (e *UploadTooLargeError) Error() string {
+	return fmt.Sprintf("%s is %d bytes, larger than the upload limit of %d bytes", e.Name, e.Size, e.Limit)
+}
+
+// SyncDirection is the direction FileService.SyncDir copies in.
+type SyncDirection int
+
+const (
+	// SyncPush makes the server directory match the local one.
+	SyncPush SyncDirection = iota
+	// SyncPull makes the local directory match the server one.
+	SyncPull
+)
+
+// SyncOptions configures FileService.SyncDir.
+type SyncOptions struct {
+	Direction SyncDirection
+	// Exclude holds path.Match patterns for entries to leave alone on both
+	// sides. A pattern without a slash matches names at any depth, e.g.
+	// "*.log"; one with a slash matches the path relative to the synced
+	// directories, e.g. "plugins/*/cache". Excluding a directory excludes
+	// everything in it.
+	Exclude []string
+	// Delete removes destination entries that do not exist in the source.
+	Delete bool
+	// Checksum compares files of the same size by their SHA-256 instead of
+	// their modification times. Server files are downloaded to hash them.
+	Checksum bool
+	// DryRun returns the changes without making them.
+	DryRun bool
+	// MaxSize is passed to UploadOptions.MaxSize when pushing; files larger
+	// than it fail the push before anything is changed. Zero disables the
+	// check.
+	MaxSize int64
+}
+
+// SyncAction is the kind of a SyncChange.
+type SyncAction string
+
+const (
+	SyncCopy   SyncAction = "copy"
+	SyncMkdir  SyncAction = "mkdir"
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncChange is a change made, or planned in a dry run, by
+// FileService.SyncDir.
+type SyncChange struct {
+	Action SyncAction
+	// Path is relative to the synced directories and uses forward slashes.
+	Path string
+	// Size is the size of a copied file.
+	Size int64
+}
+
+func (c SyncChange) String() string {
+	if c.Action == SyncCopy {
+		return fmt.Sprintf("copy %s (%d bytes)", c.Path, c.Size)
+	}
+	return fmt.Sprintf("%s %s", c.Action, c.Path)
+}
+
+// FSOptions configures FileService.FS.
+type FSOptions struct {
+	// Root is the server directory that becomes the root of the file
+	// system. Defaults to "/".
+	Root string
+	// CacheTTL is how long a directory listing is reused before the panel
+	// is asked again. Stat and Open list the parent directory, so caching
+	// saves most requests of a walk. Zero disables the cache.
+	CacheTTL time.Duration
+}
+
+// DefaultSearchConcurrency is the number of requests FileService.Find and
+// FileService.Grep make at once unless FindOptions.Concurrency says
+// otherwise.
+const DefaultSearchConcurrency = 4
+
+// DefaultGrepMaxFileSize is the size of the largest file FileService.Grep
+// reads unless GrepOptions.MaxFileSize says otherwise. It matches the
+// panel's default limit for reading file contents.
+const DefaultGrepMaxFileSize = 4 << 20
+
+// WalkOptions configures FileService.Walk.
+type WalkOptions struct {
+	// Skip holds path.Match patterns of entries to neither report nor
+	// descend into, such as "region" or "world/playerdata". Patterns are
+	// matched like SyncOptions.Exclude.
+	Skip []string
+	// MaxDepth limits how deep directories are descended into; entries of
+	// the root directory have depth 1. Zero means no limit.
+	MaxDepth int
+}
+
+// FindOptions configures FileService.Find. Zero values disable a filter.
+type FindOptions struct {
+	WalkOptions
+	// Name is a path.Match pattern for the files to find, matched against
+	// the file name, or the path relative to the root if it contains a
+	// slash.
+	Name           string
+	MinSize        int64
+	MaxSize        int64
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	// IncludeDirs reports matching directories as well as files.
+	IncludeDirs bool
+	// Concurrency is the number of requests made at once. Zero means
+	// DefaultSearchConcurrency.
+	Concurrency int
+}
+
+// FoundFile is an entry found by FileService.Find.
+type FoundFile struct {
+	// Path is the full path on the server.
+	Path string
+	*FileObject
+}
+
+// GrepOptions configures FileService.Grep. The embedded FindOptions select
+// the files to search.
+type GrepOptions struct {
+	FindOptions
+	// MaxFileSize skips larger files. Zero means DefaultGrepMaxFileSize.
+	MaxFileSize int64
+}
+
+// GrepMatch is a line matched by FileService.Grep.
+type GrepMatch struct {
+	// Path is the full path of the file on the server.
+	Path string
+	// Line is the 1-based line number.
+	Line int
+	// Text is the line without its line ending.
+	Text string
+}
+
+func (m GrepMatch) String() string {
+	return fmt.Sprintf("%s:%d:%s", m.Path, m.Line, m.Text)
+}
+
+// FileErrors is returned by FileService.Grep, together with the matches in
+// the other files, when some files could not be read.
+type FileErrors struct {
+	// Errors holds the failures by full path on the server.
+	Errors map[string]error
+}
+
+func (e *FileErrors) Error() string {
+	paths := make([]string, 0, len(e.Errors))
+	for p := range e.Errors {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	if len(paths) == 1 {
+		return fmt.Sprintf("failed to read %s: %v", paths[0], e.Errors[paths[0]])
+	}
+	return fmt.Sprintf("failed to read %d files, including %s: %v", len(paths), paths[0], e.Errors[paths[0]])
+}
@@ -0,0 +1,37 @@
+package api_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+func TestFileObject_FileInfo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file api.FileObject
+		mode fs.FileMode
+	}{
+		{"file", api.FileObject{Name: "a.txt", ModeBits: "644", IsFile: true}, 0o644},
+		{"directory", api.FileObject{Name: "plugins", ModeBits: "755"}, fs.ModeDir | 0o755},
+		{"symlink", api.FileObject{Name: "link", ModeBits: "777", IsFile: true, IsSymlink: true}, fs.ModeSymlink | 0o777},
+		{"invalid mode bits", api.FileObject{Name: "b.txt", ModeBits: "rw", IsFile: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := tt.file.FileInfo()
+			if info.Mode() != tt.mode {
+				t.Errorf("expected mode %v, got %v", tt.mode, info.Mode())
+			}
+			if info.IsDir() != (tt.mode&fs.ModeDir != 0) {
+				t.Errorf("expected IsDir %v", !info.IsDir())
+			}
+			if info.Name() != tt.file.Name {
+				t.Errorf("expected name %q, got %q", tt.file.Name, info.Name())
+			}
+		})
+	}
+}
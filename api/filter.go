@@ -0,0 +1,152 @@
+package api
+
+// Filter narrows a list request. Each resource has its own implementation
+// (UserFilter, ServerFilter, NodeFilter, LocationFilter) listing the fields
+// the panel can filter on. Empty fields are ignored.
+type Filter interface {
+	// FilterParams returns the filters keyed by field name; NewRequest
+	// encodes each one as filter[field]=value.
+	FilterParams() map[string]string
+}
+
+// SortField is a field a list can be sorted by. Each resource has its own
+// set of constants (UserSortID, ServerSortUUID, ...).
+type SortField interface {
+	SortKey() string
+}
+
+// Sort orders a list by Field, ascending unless Descending is set.
+type Sort struct {
+	Field      SortField
+	Descending bool
+}
+
+// String returns the sort in the panel's syntax, e.g. "-id".
+func (s Sort) String() string {
+	if s.Field == nil {
+		return ""
+	}
+	if s.Descending {
+		return "-" + s.Field.SortKey()
+	}
+	return s.Field.SortKey()
+}
+
+func filterParams(pairs ...string) map[string]string {
+	params := make(map[string]string)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			params[pairs[i]] = pairs[i+1]
+		}
+	}
+	return params
+}
+
+// UserFilter filters the application users list.
+type UserFilter struct {
+	Email      string
+	UUID       string
+	Username   string
+	ExternalID string
+}
+
+func (f UserFilter) FilterParams() map[string]string {
+	return filterParams(
+		"email", f.Email,
+		"uuid", f.UUID,
+		"username", f.Username,
+		"external_id", f.ExternalID,
+	)
+}
+
+// UserSortField is a field the users list can be sorted by.
+type UserSortField string
+
+const (
+	UserSortID   UserSortField = "id"
+	UserSortUUID UserSortField = "uuid"
+)
+
+func (f UserSortField) SortKey() string { return string(f) }
+
+// ServerFilter filters the application servers list.
+type ServerFilter struct {
+	UUID        string
+	UUIDShort   string
+	Name        string
+	Description string
+	Image       string
+	ExternalID  string
+}
+
+func (f ServerFilter) FilterParams() map[string]string {
+	return filterParams(
+		"uuid", f.UUID,
+		"uuidShort", f.UUIDShort,
+		"name", f.Name,
+		"description", f.Description,
+		"image", f.Image,
+		"external_id", f.ExternalID,
+	)
+}
+
+// ServerSortField is a field the servers list can be sorted by.
+type ServerSortField string
+
+const (
+	ServerSortID   ServerSortField = "id"
+	ServerSortUUID ServerSortField = "uuid"
+)
+
+func (f ServerSortField) SortKey() string { return string(f) }
+
+// NodeFilter filters the nodes list.
+type NodeFilter struct {
+	UUID          string
+	Name          string
+	FQDN          string
+	DaemonTokenID string
+}
+
+func (f NodeFilter) FilterParams() map[string]string {
+	return filterParams(
+		"uuid", f.UUID,
+		"name", f.Name,
+		"fqdn", f.FQDN,
+		"daemon_token_id", f.DaemonTokenID,
+	)
+}
+
+// NodeSortField is a field the nodes list can be sorted by.
+type NodeSortField string
+
+const (
+	NodeSortID     NodeSortField = "id"
+	NodeSortUUID   NodeSortField = "uuid"
+	NodeSortMemory NodeSortField = "memory"
+	NodeSortDisk   NodeSortField = "disk"
+)
+
+func (f NodeSortField) SortKey() string { return string(f) }
+
+// LocationFilter filters the locations list.
+type LocationFilter struct {
+	Short string
+	Long  string
+}
+
+func (f LocationFilter) FilterParams() map[string]string {
+	return filterParams(
+		"short", f.Short,
+		"long", f.Long,
+	)
+}
+
+// LocationSortField is a field the locations list can be sorted by.
+type LocationSortField string
+
+const (
+	LocationSortID LocationSortField = "id"
+)
+
+func (f LocationSortField) SortKey() string { return string(f) }
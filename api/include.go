@@ -0,0 +1,144 @@
+package api
+
+import (
+	"strings"
+	"time"
+)
+
+// Includes converts typed include constants into the strings expected by
+// PaginationOptions.Include.
+//
+//	opts := api.PaginationOptions{Include: api.Includes(api.ServerIncludeEgg, api.ServerIncludeNode)}
+func Includes[T ~string](includes ...T) []string {
+	out := make([]string, len(includes))
+	for i, inc := range includes {
+		out[i] = string(inc)
+	}
+	return out
+}
+
+// ServerInclude is a relationship that can be included with an application
+// server.
+type ServerInclude string
+
+const (
+	ServerIncludeAllocations ServerInclude = "allocations"
+	ServerIncludeUser        ServerInclude = "user"
+	ServerIncludeSubusers    ServerInclude = "subusers"
+	ServerIncludePack        ServerInclude = "pack"
+	ServerIncludeNest        ServerInclude = "nest"
+	ServerIncludeEgg         ServerInclude = "egg"
+	ServerIncludeVariables   ServerInclude = "variables"
+	ServerIncludeLocation    ServerInclude = "location"
+	ServerIncludeNode        ServerInclude = "node"
+	ServerIncludeDatabases   ServerInclude = "databases"
+)
+
+// UserInclude is a relationship that can be included with a user.
+type UserInclude string
+
+const (
+	UserIncludeServers UserInclude = "servers"
+)
+
+// NodeInclude is a relationship that can be included with a node.
+type NodeInclude string
+
+const (
+	NodeIncludeAllocations NodeInclude = "allocations"
+	NodeIncludeLocation    NodeInclude = "location"
+	NodeIncludeServers     NodeInclude = "servers"
+)
+
+// EggInclude is a relationship that can be included with an egg.
+type EggInclude string
+
+const (
+	EggIncludeNest      EggInclude = "nest"
+	EggIncludeServers   EggInclude = "servers"
+	EggIncludeConfig    EggInclude = "config"
+	EggIncludeScript    EggInclude = "script"
+	EggIncludeVariables EggInclude = "variables"
+)
+
+// EggVariable is a variable defined by an egg.
+type EggVariable struct {
+	ID           int       `json:"id"`
+	EggID        int       `json:"egg_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	EnvVariable  string    `json:"env_variable"`
+	DefaultValue string    `json:"default_value"`
+	UserViewable bool      `json:"user_viewable"`
+	UserEditable bool      `json:"user_editable"`
+	Rules        string    `json:"rules"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Required reports whether the variable's rules include "required", meaning
+// a server cannot be created without a value for it.
+func (v *EggVariable) Required() bool {
+	for _, rule := range strings.Split(v.Rules, "|") {
+		if strings.TrimSpace(rule) == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerVariable is an egg variable together with the value a server uses.
+type ServerVariable struct {
+	EggVariable
+	ServerValue *string `json:"server_value"`
+}
+
+// ServerSubuser is a subuser as seen through the application API.
+type ServerSubuser struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"user_id"`
+	ServerID    int       `json:"server_id"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Pack is a service pack. Packs were removed in panel 1.0 and are only
+// returned by older panels.
+type Pack struct {
+	ID          int       `json:"id"`
+	UUID        string    `json:"uuid"`
+	EggID       int       `json:"egg"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Version     string    `json:"version"`
+	Selectable  bool      `json:"selectable"`
+	Visible     bool      `json:"visible"`
+	Locked      bool      `json:"locked"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// listAttributes flattens an included list, returning nil if it was not
+// included.
+func listAttributes[T any](list *PaginatedResponse[T]) []*T {
+	if list == nil {
+		return nil
+	}
+	out := make([]*T, 0, len(list.Data))
+	for _, item := range list.Data {
+		if item != nil && item.Attributes != nil {
+			out = append(out, item.Attributes)
+		}
+	}
+	return out
+}
+
+// itemAttributes flattens an included item. The panel sends a null_resource
+// with no attributes for empty relationships, which yields nil.
+func itemAttributes[T any](item *ListItem[T]) *T {
+	if item == nil {
+		return nil
+	}
+	return item.Attributes
+}
@@ -0,0 +1,78 @@
+package api
+
+import "context"
+
+// PageFunc fetches one page of a paginated list. Pages are numbered from 1.
+type PageFunc[T any] func(ctx context.Context, page int) ([]*T, *Meta, error)
+
+// Iterator walks a paginated list one item at a time, fetching the next page
+// only when the current one is exhausted. Only one page is held in memory.
+//
+//	it := client.ApplicationAPI.Users.Iter(ctx, nil)
+//	for it.Next() {
+//	    user := it.Item()
+//	    ...
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	page  int // next page to fetch
+	buf   []*T
+	cur   *T
+	meta  *Meta
+	err   error
+	done  bool
+}
+
+// NewIterator returns an Iterator starting at page startPage (1 if not
+// positive) that fetches pages with fetch.
+func NewIterator[T any](ctx context.Context, startPage int, fetch PageFunc[T]) *Iterator[T] {
+	if startPage <= 0 {
+		startPage = 1
+	}
+	return &Iterator[T]{ctx: ctx, fetch: fetch, page: startPage}
+}
+
+// Next advances to the next item, fetching a new page if needed. It returns
+// false when the list is exhausted, the context is done or a fetch failed;
+// check Err to tell these apart.
+func (it *Iterator[T]) Next() bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			it.cur = nil
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			it.cur = nil
+			return false
+		}
+		items, meta, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			it.cur = nil
+			return false
+		}
+		it.meta = meta
+		it.buf = items
+		if meta == nil || meta.Pagination.CurrentPage >= meta.Pagination.TotalPages || len(items) == 0 {
+			it.done = true
+		}
+		it.page++
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Item() *T { return it.cur }
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error { return it.err }
+
+// Meta returns the pagination metadata of the most recently fetched page, or
+// nil before the first fetch.
+func (it *Iterator[T]) Meta() *Meta { return it.meta }
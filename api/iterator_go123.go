@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package api
+
+import "iter"
+
+// All returns the remaining items as an iter.Seq2 for use with range-over-func.
+// If the iteration fails, the last pair yielded carries a nil item and the
+// error.
+//
+//	for user, err := range client.ApplicationAPI.Users.Iter(ctx, nil).All() {
+//	    if err != nil { ... }
+//	    ...
+//	}
+func (it *Iterator[T]) All() iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for it.Next() {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
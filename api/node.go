@@ -1,6 +1,9 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Node struct {
 	ID                 int       `json:"id"`
@@ -23,6 +26,42 @@ type Node struct {
 	UploadSize         int       `json:"upload_size"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+
+	Relationships *NodeRelationships `json:"relationships,omitempty"`
+
+	// The fields below are flattened from Relationships when the matching
+	// NodeInclude was requested.
+	Allocations []*Allocation `json:"-"`
+	Location    *Location     `json:"-"`
+	Servers     []*Server     `json:"-"`
+}
+
+// NodeRelationships holds the relationships requested with NodeInclude
+// values.
+type NodeRelationships struct {
+	Allocations *PaginatedResponse[Allocation] `json:"allocations,omitempty"`
+	Location    *ListItem[Location]            `json:"location,omitempty"`
+	Servers     *PaginatedResponse[Server]     `json:"servers,omitempty"`
+}
+
+// UnmarshalJSON decodes a node and flattens its included relationships.
+func (n *Node) UnmarshalJSON(data []byte) error {
+	type plain Node
+	if err := json.Unmarshal(data, (*plain)(n)); err != nil {
+		return err
+	}
+	if r := n.Relationships; r != nil {
+		n.Allocations = listAttributes(r.Allocations)
+		n.Location = itemAttributes(r.Location)
+		n.Servers = listAttributes(r.Servers)
+	}
+	return nil
+}
+
+// UploadLimit returns the largest file in bytes that Wings accepts through
+// FileService uploads on this node. UploadSize is in MiB.
+func (n *Node) UploadLimit() int64 {
+	return int64(n.UploadSize) << 20
 }
 
 type NodeConfiguration struct {
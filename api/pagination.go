@@ -29,4 +29,8 @@ type PaginationOptions struct {
 	Page    int `json:"page"`
 	PerPage int `json:"per_page"`
 	Include []string
+	// Filter restricts the list, e.g. UserFilter{Email: "alex@example.com"}.
+	Filter Filter
+	// Sort orders the list; later entries break ties in earlier ones.
+	Sort []Sort
 }
@@ -15,5 +15,6 @@ type ResourceAttributes struct {
 		DiskBytes      int     `json:"disk_bytes"`
 		NetworkRxBytes int     `json:"network_rx_bytes"`
 		NetworkTxBytes int     `json:"network_tx_bytes"`
+		Uptime         int64   `json:"uptime"` // milliseconds
 	} `json:"resources"`
 }
@@ -1,8 +1,22 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
+// ServerRelationships holds the relationships requested with ServerInclude
+// values. Each field is nil unless it was included.
 type ServerRelationships struct {
+	Allocations *PaginatedResponse[Allocation]     `json:"allocations,omitempty"`
+	User        *ListItem[User]                    `json:"user,omitempty"`
+	Subusers    *PaginatedResponse[ServerSubuser]  `json:"subusers,omitempty"`
+	Pack        *ListItem[Pack]                    `json:"pack,omitempty"`
+	Nest        *ListItem[Nest]                    `json:"nest,omitempty"`
+	Egg         *ListItem[Egg]                     `json:"egg,omitempty"`
+	Variables   *PaginatedResponse[ServerVariable] `json:"variables,omitempty"`
+	Location    *ListItem[Location]                `json:"location,omitempty"`
+	Node        *ListItem[Node]                    `json:"node,omitempty"`
 	// The API returns a paginated-like list for databases.
 	// We use a pointer because this field may not always be present.
 	Databases *PaginatedResponse[Database] `json:"databases,omitempty"`
@@ -28,6 +42,41 @@ type Server struct {
 	CreatedAt     time.Time           `json:"created_at"`
 
 	Relationships *ServerRelationships `json:"relationships,omitempty"`
+
+	// The fields below are flattened from Relationships when the matching
+	// ServerInclude was requested. The Details suffix avoids clashing with
+	// the ID fields above.
+	Allocations []*Allocation     `json:"-"`
+	UserDetails *User             `json:"-"`
+	Subusers    []*ServerSubuser  `json:"-"`
+	Pack        *Pack             `json:"-"`
+	NestDetails *Nest             `json:"-"`
+	EggDetails  *Egg              `json:"-"`
+	Variables   []*ServerVariable `json:"-"`
+	Location    *Location         `json:"-"`
+	NodeDetails *Node             `json:"-"`
+	Databases   []*Database       `json:"-"`
+}
+
+// UnmarshalJSON decodes a server and flattens its included relationships.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	if r := s.Relationships; r != nil {
+		s.Allocations = listAttributes(r.Allocations)
+		s.UserDetails = itemAttributes(r.User)
+		s.Subusers = listAttributes(r.Subusers)
+		s.Pack = itemAttributes(r.Pack)
+		s.NestDetails = itemAttributes(r.Nest)
+		s.EggDetails = itemAttributes(r.Egg)
+		s.Variables = listAttributes(r.Variables)
+		s.Location = itemAttributes(r.Location)
+		s.NodeDetails = itemAttributes(r.Node)
+		s.Databases = listAttributes(r.Databases)
+	}
+	return nil
 }
 
 type ServerLimits struct {
@@ -135,5 +184,23 @@ type SendCommandOptions struct {
 }
 
 type SetPowerStateOptions struct {
-	Signal string `json:"signal"` // "start", "stop", "restart", "kill"
+	Signal PowerSignal `json:"signal"`
 }
+
+// PowerSignal is a power action accepted by the panel and by Wings.
+type PowerSignal string
+
+const (
+	PowerStart   PowerSignal = "start"
+	PowerStop    PowerSignal = "stop"
+	PowerRestart PowerSignal = "restart"
+	PowerKill    PowerSignal = "kill"
+)
+
+// Server states reported by Wings, e.g. in ResourceAttributes.CurrentState.
+const (
+	ServerStateOffline  = "offline"
+	ServerStateStarting = "starting"
+	ServerStateRunning  = "running"
+	ServerStateStopping = "stopping"
+)
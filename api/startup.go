@@ -5,9 +5,11 @@ type StartupVariable struct {
 	Description  string `json:"description"`
 	EnvVariable  string `json:"env_variable"`
 	DefaultValue string `json:"default_value"`
-	ServerValue  string `json:"server_value"`
-	IsEditable   bool   `json:"is_editable"`
-	Rules        string `json:"rules"`
+	// ServerValue is nil when the server has no value of its own, which is
+	// not the same as an empty one.
+	ServerValue *string `json:"server_value"`
+	IsEditable  bool    `json:"is_editable"`
+	Rules       string  `json:"rules"`
 }
 
 type UpdateVariableOptions struct {
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StartupContext holds the values Wings makes available to a server's
+// startup command.
+//
+// Wings starts the container with the egg variables as environment
+// variables, plus SERVER_MEMORY, SERVER_IP, SERVER_PORT, TZ and the panel's
+// P_SERVER_UUID, P_SERVER_LOCATION and P_SERVER_ALLOCATION_LIMIT, and the image's
+// entrypoint rewrites {{NAME}} to ${NAME} before evaluating the command in a
+// shell. Render reproduces that substitution so the effective command can be
+// shown, and unresolved variables caught, before the server is started.
+type StartupContext struct {
+	// Memory is the memory limit in MiB, exposed as SERVER_MEMORY.
+	Memory int
+	// IP and Port are the primary allocation, exposed as SERVER_IP and
+	// SERVER_PORT.
+	IP   string
+	Port int
+	// UUID is the server's UUID, exposed as P_SERVER_UUID.
+	UUID string
+	// Location is the short code of the node's location, exposed as
+	// P_SERVER_LOCATION.
+	Location string
+	// AllocationLimit is the number of allocations the server may have,
+	// exposed as P_SERVER_ALLOCATION_LIMIT. Nil if unknown.
+	AllocationLimit *int
+	// Timezone is the Wings host's configured timezone, exposed as TZ. The
+	// panel does not report it.
+	Timezone string
+	// Variables are the egg variables keyed by environment variable name.
+	Variables map[string]string
+}
+
+// StartupContext returns the startup context of an application server. The
+// primary allocation is only known if the server was fetched with
+// ServerIncludeAllocations, and the location with ServerIncludeLocation;
+// otherwise SERVER_IP and SERVER_PORT are left unresolved and
+// P_SERVER_LOCATION comes from the container environment, if the panel
+// included it there.
+func (s *Server) StartupContext() StartupContext {
+	allocationLimit := s.FeatureLimits.Allocations
+	c := StartupContext{
+		Memory:          s.Limits.Memory,
+		UUID:            s.UUID,
+		AllocationLimit: &allocationLimit,
+		Variables:       make(map[string]string, len(s.Container.Environment)),
+	}
+	if s.Location != nil {
+		c.Location = s.Location.ShortCode
+	}
+	for _, a := range s.Allocations {
+		if a.ID == s.Allocation {
+			c.IP, c.Port = a.IP, a.Port
+		}
+	}
+	for k, v := range s.Container.Environment {
+		c.Variables[k] = environmentValue(v)
+	}
+	return c
+}
+
+// NewStartupContext returns the startup context of a server as seen through
+// the client API, from its limits, primary allocation (the one with
+// IsDefault set) and startup variables. Variables without a server value use
+// their default, as the panel does when configuring Wings; a server value
+// set to "" stays empty. The client API
+// does not expose the server's location or allocation limit; set UUID,
+// Location, AllocationLimit and Timezone on the result when they are known.
+func NewStartupContext(limits ServerLimits, allocation *Allocation, variables []*StartupVariable) StartupContext {
+	c := StartupContext{Memory: limits.Memory, Variables: make(map[string]string, len(variables))}
+	if allocation != nil {
+		c.IP, c.Port = allocation.IP, allocation.Port
+	}
+	for _, v := range variables {
+		value := v.DefaultValue
+		if v.ServerValue != nil {
+			value = *v.ServerValue
+		}
+		c.Variables[v.EnvVariable] = value
+	}
+	return c
+}
+
+// Environment returns the environment Wings passes to the container. Names
+// are upper-cased and the fields of c take precedence over egg variables of
+// the same name. Fields that are unknown are omitted.
+func (c StartupContext) Environment() map[string]string {
+	env := make(map[string]string, len(c.Variables)+7)
+	for k, v := range c.Variables {
+		env[strings.ToUpper(k)] = v
+	}
+	env["SERVER_MEMORY"] = strconv.Itoa(c.Memory)
+	if c.IP != "" {
+		env["SERVER_IP"] = c.IP
+	}
+	if c.Port != 0 {
+		env["SERVER_PORT"] = strconv.Itoa(c.Port)
+	}
+	if c.UUID != "" {
+		env["P_SERVER_UUID"] = c.UUID
+	}
+	if c.Location != "" {
+		env["P_SERVER_LOCATION"] = c.Location
+	}
+	if c.AllocationLimit != nil {
+		env["P_SERVER_ALLOCATION_LIMIT"] = strconv.Itoa(*c.AllocationLimit)
+	}
+	if c.Timezone != "" {
+		env["TZ"] = c.Timezone
+	}
+	return env
+}
+
+// runtimeVariables are always set in the container, whether or not the
+// StartupContext knows their values.
+var runtimeVariables = map[string]bool{
+	"TZ":                        true,
+	"P_SERVER_UUID":             true,
+	"P_SERVER_LOCATION":         true,
+	"P_SERVER_ALLOCATION_LIMIT": true,
+}
+
+// startupPlaceholder matches {{NAME}}, ${NAME} and $NAME. The braced forms
+// capture anything up to the closing braces so that malformed names are
+// reported rather than left in the command.
+var startupPlaceholder = regexp.MustCompile(`\{\{([^{}]*)\}\}|\$\{([^{}]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Render substitutes the environment into a startup command. Placeholders
+// that cannot be resolved are replaced with an empty string, as the shell
+// would, and reported in an *UnresolvedVariablesError returned alongside the
+// rendered command. Variables Wings always sets but c does not know, such as
+// TZ, are not reported and are left in the command as ${NAME}.
+func (c StartupContext) Render(command string) (string, error) {
+	env := c.Environment()
+	var (
+		b          strings.Builder
+		unresolved []string
+		last       int
+	)
+	for _, m := range startupPlaceholder.FindAllStringSubmatchIndex(command, -1) {
+		b.WriteString(command[last:m[0]])
+		last = m[1]
+
+		var name string
+		for g := 1; g <= 3; g++ {
+			if m[2*g] >= 0 {
+				name = command[m[2*g]:m[2*g+1]]
+				break
+			}
+		}
+		if value, ok := env[name]; ok && variableName.MatchString(name) {
+			b.WriteString(value)
+			continue
+		}
+		if runtimeVariables[name] {
+			b.WriteString("${" + name + "}")
+			continue
+		}
+		unresolved = append(unresolved, name)
+	}
+	b.WriteString(command[last:])
+
+	if len(unresolved) > 0 {
+		return b.String(), &UnresolvedVariablesError{Names: dedupe(unresolved)}
+	}
+	return b.String(), nil
+}
+
+// UnresolvedVariablesError is returned by StartupContext.Render when the
+// command refers to variables that are not set.
+type UnresolvedVariablesError struct {
+	// Names are the unresolved variables in order of first use.
+	Names []string
+}
+
+func (e *UnresolvedVariablesError) Error() string {
+	quoted := make([]string, len(e.Names))
+	for i, n := range e.Names {
+		quoted[i] = strconv.Quote(n)
+	}
+	return fmt.Sprintf("unresolved startup variables: %s", strings.Join(quoted, ", "))
+}
+
+// environmentValue formats a value from ServerContainer.Environment, which
+// holds whatever JSON type the panel returned.
+func environmentValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := names[:0]
+	for _, n := range names {
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
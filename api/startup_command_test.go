@@ -0,0 +1,137 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+func TestStartupContext_Render(t *testing.T) {
+	t.Parallel()
+
+	c := api.StartupContext{
+		Memory: 1024,
+		IP:     "10.0.0.5",
+		Port:   25565,
+		Variables: map[string]string{
+			"SERVER_JARFILE": "paper.jar",
+			"BUILD":          "",
+			"server_memory":  "9999",
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		command    string
+		want       string
+		unresolved []string
+	}{
+		{
+			name:    "panel placeholders",
+			command: "java -Xms128M -Xmx{{SERVER_MEMORY}}M -jar {{SERVER_JARFILE}}",
+			want:    "java -Xms128M -Xmx1024M -jar paper.jar",
+		},
+		{
+			name:    "shell variables",
+			command: "./server --ip ${SERVER_IP} --port $SERVER_PORT --build=$BUILD",
+			want:    "./server --ip 10.0.0.5 --port 25565 --build=",
+		},
+		{
+			name:    "runtime variables",
+			command: "TZ={{TZ}} ./server --id ${P_SERVER_UUID}",
+			want:    "TZ=${TZ} ./server --id ${P_SERVER_UUID}",
+		},
+		{
+			name:       "unresolved",
+			command:    "./server {{MISSING}} ${MISSING} {{ SERVER_PORT }} $OTHER",
+			want:       "./server    ",
+			unresolved: []string{"MISSING", " SERVER_PORT ", "OTHER"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := c.Render(tc.command)
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+			if tc.unresolved == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var uerr *api.UnresolvedVariablesError
+			if !errors.As(err, &uerr) {
+				t.Fatalf("expected an UnresolvedVariablesError, got %v", err)
+			}
+			if !reflect.DeepEqual(uerr.Names, tc.unresolved) {
+				t.Errorf("expected unresolved %q, got %q", tc.unresolved, uerr.Names)
+			}
+		})
+	}
+}
+
+func TestServer_StartupContext(t *testing.T) {
+	t.Parallel()
+
+	s := &api.Server{
+		UUID:          "1a7ce997-259b-452e-8b4e-cecc464142ca",
+		Allocation:    2,
+		Limits:        api.ServerLimits{Memory: 2048},
+		FeatureLimits: api.ServerFeatureLimits{Allocations: 3},
+		Location:      &api.Location{ShortCode: "fra1"},
+		Container: api.ServerContainer{Environment: map[string]any{
+			"SERVER_JARFILE":            "server.jar",
+			"P_SERVER_ALLOCATION_LIMIT": float64(1),
+			"MAX_PLAYERS":               nil,
+		}},
+		Allocations: []*api.Allocation{
+			{ID: 1, IP: "10.0.0.5", Port: 25566},
+			{ID: 2, IP: "10.0.0.5", Port: 25565},
+		},
+	}
+	env := s.StartupContext().Environment()
+	want := map[string]string{
+		"SERVER_MEMORY":             "2048",
+		"SERVER_IP":                 "10.0.0.5",
+		"SERVER_PORT":               "25565",
+		"SERVER_JARFILE":            "server.jar",
+		"P_SERVER_ALLOCATION_LIMIT": "3",
+		"P_SERVER_UUID":             "1a7ce997-259b-452e-8b4e-cecc464142ca",
+		"P_SERVER_LOCATION":         "fra1",
+		"MAX_PLAYERS":               "",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("unexpected environment: %v", env)
+	}
+
+	// Without the allocations include the address cannot be resolved.
+	s.Allocations = nil
+	if _, err := s.StartupContext().Render("{{SERVER_IP}}:{{SERVER_PORT}}"); err == nil {
+		t.Error("expected SERVER_IP and SERVER_PORT to be unresolved")
+	}
+}
+
+func TestNewStartupContext(t *testing.T) {
+	t.Parallel()
+
+	level, extraFlags := "survival", ""
+	c := api.NewStartupContext(api.ServerLimits{Memory: 512}, &api.Allocation{IP: "0.0.0.0", Port: 19132, IsDefault: true},
+		[]*api.StartupVariable{
+			{EnvVariable: "LEVEL", DefaultValue: "world", ServerValue: &level},
+			{EnvVariable: "VERSION", DefaultValue: "latest"},
+			{EnvVariable: "EXTRA_FLAGS", DefaultValue: "--nogui", ServerValue: &extraFlags},
+		})
+	c.Timezone = "Europe/Berlin"
+	got, err := c.Render("TZ={{TZ}} ./bedrock --port {{SERVER_PORT}} --level {{LEVEL}} --version {{VERSION}} --mem {{SERVER_MEMORY}}{{EXTRA_FLAGS}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "TZ=Europe/Berlin ./bedrock --port 19132 --level survival --version latest --mem 512"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -1,6 +1,9 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type User struct {
 	ID         int       `json:"id"`
@@ -15,6 +18,30 @@ type User struct {
 	TwoFA      bool      `json:"2fa"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	Relationships *UserRelationships `json:"relationships,omitempty"`
+
+	// Servers is flattened from Relationships when UserIncludeServers was
+	// requested.
+	Servers []*Server `json:"-"`
+}
+
+// UserRelationships holds the relationships requested with UserInclude
+// values.
+type UserRelationships struct {
+	Servers *PaginatedResponse[Server] `json:"servers,omitempty"`
+}
+
+// UnmarshalJSON decodes a user and flattens its included relationships.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+	if r := u.Relationships; r != nil {
+		u.Servers = listAttributes(r.Servers)
+	}
+	return nil
 }
 
 type UserCreateOptions struct {
@@ -29,6 +29,12 @@ func (s *allocationsService) ListAll(ctx context.Context) ([]*api.Allocation, er
 	return crud.ListAll[api.Allocation](ctx, s.client, endpoint, 100)
 }
 
+// Iter returns an iterator that fetches allocations one page at a time.
+func (s *allocationsService) Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Allocation] {
+	endpoint := fmt.Sprintf("/api/application/nodes/%d/allocations", s.nodeID)
+	return crud.Iterate[api.Allocation](ctx, s.client, endpoint, options)
+}
+
 func (s *allocationsService) Create(ctx context.Context, options api.AllocationCreateOptions) error {
 	// Marshal the Options struct into JSON.
 	jsonBytes, err := json.Marshal(options)
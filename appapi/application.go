@@ -8,12 +8,15 @@ import (
 type AllocationsService interface {
 	List(ctx context.Context, options *api.PaginationOptions) ([]*api.Allocation, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.Allocation, error)
+	Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Allocation]
 	Create(ctx context.Context, options api.AllocationCreateOptions) error //TODO Include int / allocation on return?
 	Delete(ctx context.Context, allocationID int) error
 }
 
 type DatabaseService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.Database, *api.Meta, error)
+	ListAll(ctx context.Context) ([]*api.Database, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Database]
 	Get(ctx context.Context, databaseID int) (*api.Database, error)
 	Create(ctx context.Context, options api.DatabaseCreateOptions) (*api.Database, error)
 	ResetPassword(ctx context.Context, databaseID int) error
@@ -23,7 +26,8 @@ type DatabaseService interface {
 type NodesService interface {
 	List(ctx context.Context, options *api.PaginationOptions) ([]*api.Node, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.Node, error)
-	Get(ctx context.Context, id int) (*api.Node, error)
+	Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Node]
+	Get(ctx context.Context, id int, includes ...api.NodeInclude) (*api.Node, error)
 	GetConfiguration(ctx context.Context, nodeID int) (*api.NodeConfiguration, error)
 	Create(ctx context.Context, options api.NodeCreateOptions) (*api.Node, error)
 	Update(ctx context.Context, nodeID int, options api.NodeUpdateOptions) (*api.Node, error)
@@ -34,13 +38,15 @@ type NodesService interface {
 type EggsService interface {
 	List(ctx context.Context, options *api.PaginationOptions) ([]*api.Egg, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.Egg, error)
-	Get(ctx context.Context, eggID int) (*api.Egg, error)
+	Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Egg]
+	Get(ctx context.Context, eggID int, includes ...api.EggInclude) (*api.Egg, error)
 }
 
 // NestsService defines the actions for nests and provides access to egg management.
 type NestsService interface {
 	List(ctx context.Context, options *api.PaginationOptions) ([]*api.Nest, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.Nest, error)
+	Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Nest]
 	Get(ctx context.Context, id int) (*api.Nest, error)
 	Eggs(nestID int) EggsService // Returns the EggsService interface
 }
@@ -49,7 +55,8 @@ type NestsService interface {
 type UsersService interface {
 	List(ctx context.Context, options *api.PaginationOptions) ([]*api.User, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.User, error)
-	Get(ctx context.Context, id int) (*api.User, error)
+	Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.User]
+	Get(ctx context.Context, id int, includes ...api.UserInclude) (*api.User, error)
 	GetExternalID(ctx context.Context, externalId string) (*api.User, error)
 	Create(ctx context.Context, options api.UserCreateOptions) (*api.User, error)
 	Update(ctx context.Context, id int, options api.UserUpdateOptions) (*api.User, error)
@@ -59,7 +66,8 @@ type UsersService interface {
 type ServersService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.Server, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.Server, error)
-	Get(ctx context.Context, id int) (*api.Server, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Server]
+	Get(ctx context.Context, id int, includes ...api.ServerInclude) (*api.Server, error)
 	GetExternal(ctx context.Context, externalID string) (*api.Server, error)
 	Create(ctx context.Context, options api.ServerCreateOptions) (*api.Server, error)
 	UpdateDetails(ctx context.Context, serverID int, options api.ServerUpdateDetailsOptions) (*api.Server, error)
@@ -76,6 +84,7 @@ type ServersService interface {
 type LocationsService interface {
 	List(ctx context.Context, options *api.PaginationOptions) ([]*api.Location, *api.Meta, error)
 	ListAll(ctx context.Context) ([]*api.Location, error)
+	Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Location]
 	Get(ctx context.Context, id int) (*api.Location, error)
 	Create(ctx context.Context, options api.LocationCreateOptions) (*api.Location, error)
 	Update(ctx context.Context, id int, options api.LocationUpdateOptions) (*api.Location, error)
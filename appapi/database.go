@@ -24,6 +24,18 @@ func (s *databaseService) List(ctx context.Context, options api.PaginationOption
 	return crud.List[api.Database](ctx, s.client, endpoint, &options)
 }
 
+// ListAll fetches every database of the server, following pagination.
+func (s *databaseService) ListAll(ctx context.Context) ([]*api.Database, error) {
+	endpoint := fmt.Sprintf("/api/application/servers/%d/databases", s.serverID)
+	return crud.ListAll[api.Database](ctx, s.client, endpoint, 100)
+}
+
+// Iter returns an iterator that fetches databases one page at a time.
+func (s *databaseService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Database] {
+	endpoint := fmt.Sprintf("/api/application/servers/%d/databases", s.serverID)
+	return crud.Iterate[api.Database](ctx, s.client, endpoint, &options)
+}
+
 // Get fetches a single database by its ID for the configured server.
 func (s *databaseService) Get(ctx context.Context, databaseID int) (*api.Database, error) {
 	endpoint := fmt.Sprintf("/api/application/servers/%d/databases", s.serverID)
@@ -27,7 +27,15 @@ func (s *eggsService) ListAll(ctx context.Context) ([]*api.Egg, error) {
 	return crud.ListAll[api.Egg](ctx, s.client, endpoint, 100)
 }
 
-func (s *eggsService) Get(ctx context.Context, eggID int) (*api.Egg, error) {
+// Iter returns an iterator that fetches eggs one page at a time.
+func (s *eggsService) Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Egg] {
 	endpoint := fmt.Sprintf("/api/application/nests/%d/eggs", s.nestID)
-	return crud.Get[api.Egg](ctx, s.client, endpoint, eggID)
+	return crud.Iterate[api.Egg](ctx, s.client, endpoint, options)
+}
+
+// Get fetches an egg together with any requested relationships, which are
+// flattened onto the returned Egg.
+func (s *eggsService) Get(ctx context.Context, eggID int, includes ...api.EggInclude) (*api.Egg, error) {
+	endpoint := fmt.Sprintf("/api/application/nests/%d/eggs", s.nestID)
+	return crud.Get[api.Egg](ctx, s.client, endpoint, eggID, api.Includes(includes...)...)
 }
@@ -904,3 +904,120 @@ func TestEggsService_Constructor(t *testing.T) {
 		t.Errorf("expected path %s, got %s", expectedPath, mock.Requests[0].Endpoint)
 	}
 }
+
+func TestEggsService_Get_Includes(t *testing.T) {
+	t.Parallel()
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{{
+		StatusCode: 200,
+		Body: []byte(`{"object": "egg", "attributes": {"id": 5, "nest_id": 1,
+			"relationships": {
+				"nest": {"object": "nest", "attributes": {"id": 1, "name": "Minecraft"}},
+				"script": {"object": "egg_script", "attributes": {"privileged": true, "container": "alpine:3", "entry": "ash"}},
+				"variables": {"object": "list", "data": [
+					{"object": "egg_variable", "attributes": {"id": 1, "egg_id": 5, "env_variable": "SERVER_JARFILE", "rules": "required|string"}},
+					{"object": "egg_variable", "attributes": {"id": 2, "egg_id": 5, "env_variable": "VERSION", "rules": "nullable|string"}}
+				]}
+			}}}`),
+	}}}
+	service := NewEggsService(mock, 1)
+	egg, err := service.Get(context.Background(), 5, api.EggIncludeNest, api.EggIncludeScript, api.EggIncludeVariables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(mock.Requests[0].Options.Include, ","); got != "nest,script,variables" {
+		t.Errorf("expected includes nest,script,variables, got %q", got)
+	}
+	if egg.Nest == nil || egg.Nest.Name != "Minecraft" {
+		t.Errorf("expected included nest, got %+v", egg.Nest)
+	}
+	if egg.ScriptDetails == nil || !egg.ScriptDetails.Privileged || egg.ScriptDetails.Container != "alpine:3" {
+		t.Errorf("expected included script, got %+v", egg.ScriptDetails)
+	}
+	if len(egg.Variables) != 2 || egg.Variables[1].EnvVariable != "VERSION" {
+		t.Errorf("expected two included variables, got %+v", egg.Variables)
+	}
+	if egg.ConfigDetails != nil || egg.Servers != nil {
+		t.Error("expected relationships that were not included to be nil")
+	}
+}
+
+func TestEggsService_Get_TypedConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config string
+	}{
+		{
+			name: "decoded config",
+			config: `{
+				"files": {"server.properties": {"parser": "properties", "find": {"server-port": "{{server.build.default.port}}"}}},
+				"startup": {"done": ")! For help, type "},
+				"stop": "stop",
+				"logs": {},
+				"file_denylist": [],
+				"extends": null
+			}`,
+		},
+		{
+			name: "string encoded config",
+			config: `{
+				"files": "{\"server.properties\":{\"parser\":\"properties\",\"find\":{\"server-port\":\"{{server.build.default.port}}\"}}}",
+				"startup": "{\"done\":[\")! For help, type \"]}",
+				"stop": "stop",
+				"logs": "{}"
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &testutil.MockRequester{Responses: []testutil.MockResponse{{
+				StatusCode: 200,
+				Body: []byte(`{"object": "egg", "attributes": {"id": 1, "name": "Paper",
+					"config": ` + tc.config + `,
+					"startup": "java -jar {{SERVER_JARFILE}}",
+					"script": {"privileged": true, "install": "echo hi", "entry": "bash", "container": "alpine:3", "extends": 2}}}`),
+			}}}
+			egg, err := NewEggsService(mock, 1).Get(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if egg.Name != "Paper" || egg.Startup != "java -jar {{SERVER_JARFILE}}" {
+				t.Errorf("unexpected name or startup: %q, %q", egg.Name, egg.Startup)
+			}
+			file, ok := egg.Config.Files["server.properties"]
+			if !ok || file.Parser != "properties" || file.Find["server-port"] != "{{server.build.default.port}}" {
+				t.Errorf("unexpected config files: %+v", egg.Config.Files)
+			}
+			if len(egg.Config.Startup.Done) != 1 || egg.Config.Startup.Done[0] != ")! For help, type " {
+				t.Errorf("unexpected startup detection: %+v", egg.Config.Startup)
+			}
+			if egg.Config.Stop != "stop" {
+				t.Errorf("expected stop command 'stop', got %q", egg.Config.Stop)
+			}
+			if !egg.Script.Privileged || egg.Script.Entry != "bash" || egg.Script.Extends == nil || *egg.Script.Extends != 2 {
+				t.Errorf("unexpected script: %+v", egg.Script)
+			}
+		})
+	}
+}
+
+func TestEgg_RequiredVariables(t *testing.T) {
+	t.Parallel()
+
+	egg := &api.Egg{Variables: []*api.EggVariable{
+		{EnvVariable: "SERVER_JARFILE", DefaultValue: "server.jar", Rules: "required|regex:/^([\\w\\d._-]+)(\\.jar)$/"},
+		{EnvVariable: "VERSION", DefaultValue: "latest", Rules: "nullable|string|max:20"},
+		{EnvVariable: "EULA", Rules: "required | in:true"},
+	}}
+
+	required := egg.RequiredVariables()
+	if len(required) != 2 || required[0].EnvVariable != "SERVER_JARFILE" || required[1].EnvVariable != "EULA" {
+		t.Errorf("unexpected required variables: %+v", required)
+	}
+	env := egg.DefaultEnvironment()
+	if len(env) != 3 || env["VERSION"] != "latest" || env["EULA"] != "" {
+		t.Errorf("unexpected default environment: %+v", env)
+	}
+}
@@ -26,6 +26,11 @@ func (s *LocationService) ListAll(ctx context.Context) ([]*api.Location, error)
 	return crud.ListAll[api.Location](ctx, s.client, "/api/application/locations", 100)
 }
 
+// Iter returns an iterator that fetches locations one page at a time.
+func (s *LocationService) Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Location] {
+	return crud.Iterate[api.Location](ctx, s.client, "/api/application/locations", options)
+}
+
 func (s *LocationService) Get(ctx context.Context, id int) (*api.Location, error) {
 	return crud.Get[api.Location](ctx, s.client, "/api/application/locations", id)
 }
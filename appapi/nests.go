@@ -27,6 +27,11 @@ func (s *nestsService) ListAll(ctx context.Context) ([]*api.Nest, error) {
 	return crud.ListAll[api.Nest](ctx, s.client, "/api/application/nests", 100)
 }
 
+// Iter returns an iterator that fetches nests one page at a time.
+func (s *nestsService) Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Nest] {
+	return crud.Iterate[api.Nest](ctx, s.client, "/api/application/nests", options)
+}
+
 // Get fetches a single nest by its ID.
 func (s *nestsService) Get(ctx context.Context, nestID int) (*api.Nest, error) {
 	return crud.Get[api.Nest](ctx, s.client, "/api/application/nests", nestID)
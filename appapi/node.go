@@ -26,8 +26,15 @@ func (s *nodesService) ListAll(ctx context.Context) ([]*api.Node, error) {
 	return crud.ListAll[api.Node](ctx, s.client, "/api/application/nodes", 100)
 }
 
-func (s *nodesService) Get(ctx context.Context, id int) (*api.Node, error) {
-	return crud.Get[api.Node](ctx, s.client, "/api/application/nodes", id)
+// Iter returns an iterator that fetches nodes one page at a time.
+func (s *nodesService) Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.Node] {
+	return crud.Iterate[api.Node](ctx, s.client, "/api/application/nodes", options)
+}
+
+// Get fetches a node together with any requested relationships, which are
+// flattened onto the returned Node.
+func (s *nodesService) Get(ctx context.Context, id int, includes ...api.NodeInclude) (*api.Node, error) {
+	return crud.Get[api.Node](ctx, s.client, "/api/application/nodes", id, api.Includes(includes...)...)
 }
 
 func (s *nodesService) GetConfiguration(ctx context.Context, nodeID int) (*api.NodeConfiguration, error) {
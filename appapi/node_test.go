@@ -477,3 +477,35 @@ func TestNodesService_DataValidation(t *testing.T) {
 		t.Errorf("expected UpdatedAt %v, got %v", expectedUpdatedAt, node.UpdatedAt)
 	}
 }
+
+func TestNodesService_Get_Includes(t *testing.T) {
+	t.Parallel()
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{{
+		StatusCode: 200,
+		Body: []byte(`{"object": "node", "attributes": {"id": 1, "name": "node-1", "location_id": 2,
+			"relationships": {
+				"location": {"object": "location", "attributes": {"id": 2, "short": "eu"}},
+				"servers": {"object": "list", "data": [
+					{"object": "server", "attributes": {"id": 7, "name": "a"}},
+					{"object": "server", "attributes": {"id": 8, "name": "b"}}
+				]}
+			}}}`),
+	}}}
+	service := NewNodesService(mock)
+	node, err := service.Get(context.Background(), 1, api.NodeIncludeLocation, api.NodeIncludeServers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(mock.Requests[0].Options.Include, ","); got != "location,servers" {
+		t.Errorf("expected includes location,servers, got %q", got)
+	}
+	if node.Location == nil || node.Location.ShortCode != "eu" {
+		t.Errorf("expected included location, got %+v", node.Location)
+	}
+	if len(node.Servers) != 2 || node.Servers[1].ID != 8 {
+		t.Errorf("expected two included servers, got %+v", node.Servers)
+	}
+	if node.Allocations != nil {
+		t.Error("expected allocations that were not included to be nil")
+	}
+}
@@ -29,9 +29,16 @@ func (s *serversService) ListAll(ctx context.Context) ([]*api.Server, error) {
 	return crud.ListAll[api.Server](ctx, s.client, "/api/application/servers", 100)
 }
 
-func (s *serversService) Get(ctx context.Context, id int) (*api.Server, error) {
+// Iter returns an iterator that fetches servers one page at a time.
+func (s *serversService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Server] {
+	return crud.Iterate[api.Server](ctx, s.client, "/api/application/servers", &options)
+}
+
+// Get fetches a server together with any requested relationships, which are
+// flattened onto the returned Server.
+func (s *serversService) Get(ctx context.Context, id int, includes ...api.ServerInclude) (*api.Server, error) {
 	endpoint := "/api/application/servers"
-	return crud.Get[api.Server](ctx, s.client, endpoint, id)
+	return crud.Get[api.Server](ctx, s.client, endpoint, id, api.Includes(includes...)...)
 }
 
 func (s *serversService) GetExternal(ctx context.Context, externalID string) (*api.Server, error) {
@@ -3,6 +3,7 @@ package appapi
 import (
 	"context"
 	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+	"strings"
 	"testing"
 
 	"github.com/davidarkless/go-pterodactyl/api"
@@ -1015,3 +1016,71 @@ func TestNewServersService(t *testing.T) {
 		t.Fatal("expected service to be non-nil")
 	}
 }
+
+func TestServersService_Get_Includes(t *testing.T) {
+	t.Parallel()
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{{
+		StatusCode: 200,
+		Body: []byte(`{"object": "server", "attributes": {"id": 1, "name": "TestServer", "user": 2, "node": 3, "egg": 4,
+			"relationships": {
+				"user": {"object": "user", "attributes": {"id": 2, "username": "owner"}},
+				"node": {"object": "node", "attributes": {"id": 3, "name": "node-1"}},
+				"egg": {"object": "egg", "attributes": {"id": 4, "nest_id": 1}},
+				"location": {"object": "null_resource", "attributes": null},
+				"allocations": {"object": "list", "data": [
+					{"object": "allocation", "attributes": {"id": 10, "ip": "10.0.0.1", "port": 25565}}
+				]},
+				"variables": {"object": "list", "data": [
+					{"object": "server_variable", "attributes": {"id": 5, "env_variable": "SERVER_JARFILE", "default_value": "server.jar", "server_value": "paper.jar"}}
+				]}
+			}}}`),
+	}}}
+	service := NewServersService(mock)
+	server, err := service.Get(context.Background(), 1, api.ServerIncludeUser, api.ServerIncludeNode, api.ServerIncludeEgg,
+		api.ServerIncludeLocation, api.ServerIncludeAllocations, api.ServerIncludeVariables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := mock.Requests[0].Options
+	if opts == nil || strings.Join(opts.Include, ",") != "user,node,egg,location,allocations,variables" {
+		t.Errorf("expected includes to be sent, got %+v", opts)
+	}
+	if server.User != 2 || server.UserDetails == nil || server.UserDetails.Username != "owner" {
+		t.Errorf("expected included user, got %+v", server.UserDetails)
+	}
+	if server.NodeDetails == nil || server.NodeDetails.Name != "node-1" {
+		t.Errorf("expected included node, got %+v", server.NodeDetails)
+	}
+	if server.EggDetails == nil || server.EggDetails.ID != 4 {
+		t.Errorf("expected included egg, got %+v", server.EggDetails)
+	}
+	if server.Location != nil {
+		t.Errorf("expected a null_resource location to be nil, got %+v", server.Location)
+	}
+	if len(server.Allocations) != 1 || server.Allocations[0].Port != 25565 {
+		t.Errorf("expected one included allocation, got %+v", server.Allocations)
+	}
+	if len(server.Variables) != 1 || server.Variables[0].EnvVariable != "SERVER_JARFILE" ||
+		server.Variables[0].ServerValue == nil || *server.Variables[0].ServerValue != "paper.jar" {
+		t.Errorf("expected one included variable, got %+v", server.Variables)
+	}
+	if server.Databases != nil || server.Subusers != nil {
+		t.Error("expected relationships that were not included to be nil")
+	}
+}
+
+func TestServersService_Get_NoIncludes(t *testing.T) {
+	t.Parallel()
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{{
+		StatusCode: 200,
+		Body:       []byte(`{"object": "server", "attributes": {"id": 1}}`),
+	}}}
+	service := NewServersService(mock)
+	if _, err := service.Get(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts := mock.Requests[0].Options; opts != nil {
+		t.Errorf("expected no options without includes, got %+v", opts)
+	}
+}
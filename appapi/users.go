@@ -26,8 +26,15 @@ func (s *usersService) ListAll(ctx context.Context) ([]*api.User, error) {
 	return crud.ListAll[api.User](ctx, s.client, "/api/application/users", 100)
 }
 
-func (s *usersService) Get(ctx context.Context, id int) (*api.User, error) {
-	return crud.Get[api.User](ctx, s.client, "/api/application/users", id)
+// Iter returns an iterator that fetches users one page at a time.
+func (s *usersService) Iter(ctx context.Context, options *api.PaginationOptions) *api.Iterator[api.User] {
+	return crud.Iterate[api.User](ctx, s.client, "/api/application/users", options)
+}
+
+// Get fetches a user together with any requested relationships, which are
+// flattened onto the returned User.
+func (s *usersService) Get(ctx context.Context, id int, includes ...api.UserInclude) (*api.User, error) {
+	return crud.Get[api.User](ctx, s.client, "/api/application/users", id, api.Includes(includes...)...)
 }
 
 func (s *usersService) GetExternalID(ctx context.Context, externalId string) (*api.User, error) {
@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package appapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+)
+
+func TestUsersService_IterAll(t *testing.T) {
+	t.Parallel()
+
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+		userPage(1, 2, 1, 2), {StatusCode: 500, Body: []byte(`{"errors":[{"code":"ServerException"}]}`)},
+	}}
+
+	var ids []int
+	var iterErr error
+	for user, err := range NewUsersService(mock).Iter(context.Background(), nil).All() {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		ids = append(ids, user.ID)
+	}
+	if len(ids) != 2 || iterErr == nil {
+		t.Errorf("expected 2 users then an error, got %v and %v", ids, iterErr)
+	}
+}
@@ -449,3 +449,131 @@ func TestUsersService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func userPage(page, totalPages int, ids ...int) testutil.MockResponse {
+	items := make([]string, len(ids))
+	for i, id := range ids {
+		items[i] = fmt.Sprintf(`{"object":"user","attributes":{"id":%d}}`, id)
+	}
+	body := fmt.Sprintf(`{"object":"list","data":[%s],"meta":{"pagination":{"total":%d,"count":%d,"per_page":2,"current_page":%d,"total_pages":%d}}}`,
+		strings.Join(items, ","), totalPages*2, len(ids), page, totalPages)
+	return testutil.MockResponse{StatusCode: 200, Body: []byte(body)}
+}
+
+func TestUsersService_Iter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("walks every page", func(t *testing.T) {
+		mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+			userPage(1, 3, 1, 2), userPage(2, 3, 3, 4), userPage(3, 3, 5),
+		}}
+		it := NewUsersService(mock).Iter(context.Background(), &api.PaginationOptions{PerPage: 2})
+
+		var ids []int
+		for it.Next() {
+			ids = append(ids, it.Item().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+			t.Errorf("expected ids [1 2 3 4 5], got %v", ids)
+		}
+		for i, req := range mock.Requests {
+			if req.Options.Page != i+1 || req.Options.PerPage != 2 {
+				t.Errorf("request %d: unexpected options %+v", i, req.Options)
+			}
+		}
+	})
+
+	t.Run("stops early without fetching more pages", func(t *testing.T) {
+		mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+			userPage(1, 1000, 1, 2), userPage(2, 1000, 3, 4),
+		}}
+		it := NewUsersService(mock).Iter(context.Background(), nil)
+		for it.Next() {
+			if it.Item().ID == 2 {
+				break
+			}
+		}
+		if len(mock.Requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.Requests))
+		}
+	})
+
+	t.Run("surfaces errors", func(t *testing.T) {
+		mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+			userPage(1, 2, 1, 2), {StatusCode: 500, Body: []byte(`{"errors":[{"code":"ServerException"}]}`)},
+		}}
+		it := NewUsersService(mock).Iter(context.Background(), nil)
+		count := 0
+		for it.Next() {
+			count++
+		}
+		if count != 2 || it.Err() == nil {
+			t.Errorf("expected 2 items and an error, got %d and %v", count, it.Err())
+		}
+	})
+
+	t.Run("respects cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+			userPage(1, 2, 1, 2), userPage(2, 2, 3, 4),
+		}}
+		it := NewUsersService(mock).Iter(ctx, nil)
+		for it.Next() {
+			cancel()
+		}
+		if it.Err() != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", it.Err())
+		}
+		if len(mock.Requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.Requests))
+		}
+	})
+}
+
+func TestUsersService_List_Filter(t *testing.T) {
+	t.Parallel()
+
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{userPage(1, 1, 42)}}
+	options := &api.PaginationOptions{
+		Filter: api.UserFilter{Email: "alex@example.com"},
+		Sort:   []api.Sort{{Field: api.UserSortID, Descending: true}},
+	}
+
+	users, _, err := NewUsersService(mock).List(context.Background(), options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != 42 {
+		t.Errorf("unexpected users %+v", users)
+	}
+	sent := mock.Requests[0].Options
+	if sent == nil || sent.Filter.FilterParams()["email"] != "alex@example.com" || sent.Sort[0].String() != "-id" {
+		t.Errorf("expected filter and sort to be passed to the requester, got %+v", sent)
+	}
+}
+
+func TestUsersService_Get_Includes(t *testing.T) {
+	t.Parallel()
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{{
+		StatusCode: 200,
+		Body: []byte(`{"object": "user", "attributes": {"id": 1, "username": "owner",
+			"relationships": {"servers": {"object": "list", "data": [
+				{"object": "server", "attributes": {"id": 3, "user": 1}}
+			]}}}}`),
+	}}}
+	service := NewUsersService(mock)
+	user, err := service.Get(context.Background(), 1, api.UserIncludeServers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(mock.Requests[0].Options.Include, ","); got != "servers" {
+		t.Errorf("expected include servers, got %q", got)
+	}
+	if len(user.Servers) != 1 || user.Servers[0].ID != 3 {
+		t.Errorf("expected one included server, got %+v", user.Servers)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -55,6 +56,12 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+	retry      *RetryPolicy
+	limiter    *RateLimiter
+	middleware []Middleware
+	handler    Handler
+
+	listConcurrency int
 
 	ApplicationAPI *appapi.ApplicationAPIService
 	ClientAPI      *clientapi.ClientAPIService
@@ -101,6 +108,32 @@ func WithTimeout(d time.Duration) Option {
 	}
 }
 
+// DefaultListConcurrency is the number of pages ListAll helpers fetch in
+// parallel unless WithListConcurrency says otherwise. Pages are fetched one
+// after another by default, so fetching them concurrently, and the extra load
+// on the panel that comes with it, is opt-in.
+const DefaultListConcurrency = 1
+
+// WithListConcurrency sets how many pages ListAll helpers fetch in parallel
+// once the first page has revealed the page count. n <= 1 fetches pages one
+// after another.
+func WithListConcurrency(n int) Option {
+	return func(c *Client) { c.listConcurrency = n }
+}
+
+// ListConcurrency reports the page concurrency for ListAll helpers. With a
+// RateLimiter configured it never exceeds the limiter's burst, so that
+// prefetching does not just queue up on the limiter.
+func (c *Client) ListConcurrency() int {
+	n := c.listConcurrency
+	if c.limiter != nil {
+		if burst := c.limiter.Burst(); n > burst {
+			n = burst
+		}
+	}
+	return n
+}
+
 // NewClient validates the arguments, builds a reusable SDK instance and applies
 // any functional options.
 // baseURL must be scheme+host, apiKey must start with ptla_ or ptlc_.
@@ -128,6 +161,8 @@ func NewClient(baseURL, apiKey string, keyType KeyType, opts ...Option) (*Client
 		baseURL:    baseURL,
 		apiKey:     apiKey,
 		httpClient: &http.Client{Timeout: 10 * time.Second},
+
+		listConcurrency: DefaultListConcurrency,
 	}
 
 	// Apply caller‑supplied options
@@ -135,6 +170,8 @@ func NewClient(baseURL, apiKey string, keyType KeyType, opts ...Option) (*Client
 		o(c)
 	}
 
+	c.handler = c.chain()
+
 	// ----- wire sub‑services --------------------------------------------------
 	c.ApplicationAPI = &appapi.ApplicationAPIService{}
 	c.ApplicationAPI.Users = appapi.NewUsersService(c)
@@ -166,6 +203,22 @@ func (c *Client) NewRequest(ctx context.Context, method, endpoint string, body i
 		if len(options.Include) > 0 {
 			q.Set("include", strings.Join(options.Include, ","))
 		}
+		if options.Filter != nil {
+			for field, value := range options.Filter.FilterParams() {
+				q.Set("filter["+field+"]", value)
+			}
+		}
+		if len(options.Sort) > 0 {
+			keys := make([]string, 0, len(options.Sort))
+			for _, s := range options.Sort {
+				if key := s.String(); key != "" {
+					keys = append(keys, key)
+				}
+			}
+			if len(keys) > 0 {
+				q.Set("sort", strings.Join(keys, ","))
+			}
+		}
 		rel.RawQuery = q.Encode()
 	}
 
@@ -180,29 +233,100 @@ func (c *Client) NewRequest(ctx context.Context, method, endpoint string, body i
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	// Absolute endpoints on another host are signed Wings URLs, which carry
+	// their own token; the panel key must not leak to the node.
+	external := fullURL.Scheme != u.Scheme || fullURL.Host != u.Host
+	if !external {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
 	req.Header.Set("Accept", "application/json")
 
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return req, nil
+	return withCall(req, &Call{
+		Method:    method,
+		Endpoint:  endpoint,
+		Operation: operationName(method, endpoint),
+		Options:   options,
+		external:  external,
+	}), nil
 }
 
+// Do runs req through the middleware chain and decodes a successful response
+// into v.
 func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*http.Response, error) {
-	req = req.WithContext(ctx)
-	res, err := c.httpClient.Do(req)
+	call := callFromRequest(req)
+	call.Request = req.WithContext(ctx)
+	call.v = v
+	return c.run(ctx, call)
+}
+
+// Stream runs req through the middleware chain like Do, but returns a
+// successful response with its body open; the caller must close it. Error
+// statuses are returned as *errors.APIError with the body closed.
+//
+// The client's http.Client timeout only applies to the wait for the response
+// headers once the request body has been sent, since sending or reading a
+// large body can take arbitrarily long; use ctx to bound the transfer.
+func (c *Client) Stream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	call := callFromRequest(req)
+	call.Request = req.WithContext(ctx)
+	call.Streaming = true
+	return c.run(ctx, call)
+}
+
+func (c *Client) run(ctx context.Context, call *Call) (*http.Response, error) {
+	handler := c.handler
+	if handler == nil {
+		handler = c.chain()
+	}
+	return handler(ctx, call)
+}
+
+func (c *Client) do(ctx context.Context, call *Call) (*http.Response, error) {
+	v := call.v
+	hc, cancel := c.httpClient, context.CancelFunc(func() {})
+	req := call.Request.WithContext(ctx)
+	if call.Streaming && hc.Timeout > 0 {
+		streaming := *hc
+		streaming.Timeout = 0
+		hc = &streaming
+		ctx, cancel = context.WithCancel(ctx)
+		req = req.WithContext(ctx)
+		timer := &headerTimer{timeout: c.httpClient.Timeout, cancel: cancel}
+		defer timer.stop()
+		timer.startAfterBody(req)
+	}
+
+	res, err := c.send(ctx, hc, req, call)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	if call.capture != nil {
+		res.Body = call.capture.wrap(res.Body)
+	}
+	if call.Streaming && res.StatusCode >= 200 && res.StatusCode < 300 {
+		res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+		return res, nil
+	}
+	defer cancel()
 	defer res.Body.Close() // ignore error
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		// Error handling logic
 		apiErr := &errors.APIError{HTTPStatusCode: res.StatusCode}
-		if err = json.NewDecoder(res.Body).Decode(&apiErr); err != nil {
-			return nil, fmt.Errorf("pterodactyl: API error (status %d), failed to parse error response: %w", res.StatusCode, err)
+		body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+		if err != nil {
+			return nil, fmt.Errorf("pterodactyl: API error (status %d), failed to read error response: %w", res.StatusCode, err)
+		}
+		// Keep the raw body when it is not an error document so the status
+		// context is not lost.
+		if err = json.Unmarshal(body, apiErr); err != nil || len(apiErr.Errors) == 0 {
+			apiErr.Errors = nil
+			apiErr.RawBody = body
 		}
 		return nil, apiErr
 	}
@@ -217,6 +341,86 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*http.Respon
 	return res, nil
 }
 
+// headerTimer applies the client timeout of a streamed request to the wait
+// for its response headers. The wait starts once the request body has been
+// sent, so that uploads are bounded by their context alone.
+type headerTimer struct {
+	timeout time.Duration
+	cancel  context.CancelFunc
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+// startAfterBody starts the timer now if req has no body, or else once the
+// transport has read the body to the end or closed it.
+func (t *headerTimer) startAfterBody(req *http.Request) {
+	if req.Body == nil || req.Body == http.NoBody {
+		t.start()
+		return
+	}
+	req.Body = &sentBody{ReadCloser: req.Body, sent: t.start}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &sentBody{ReadCloser: body, sent: t.start}, nil
+		}
+	}
+}
+
+func (t *headerTimer) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer == nil && !t.stopped {
+		t.timer = time.AfterFunc(t.timeout, t.cancel)
+	}
+}
+
+func (t *headerTimer) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// sentBody reports when a request body has been read to the end or closed.
+type sentBody struct {
+	io.ReadCloser
+	sent func()
+}
+
+func (b *sentBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.sent()
+	}
+	return n, err
+}
+
+func (b *sentBody) Close() error {
+	b.sent()
+	return b.ReadCloser.Close()
+}
+
+// cancelOnClose releases the context of a streamed request once its body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 // unmarshalList is an internal helper that decodes a paginated list response
 // from the Pterodactyl API and flattens it into a simple slice of models.
 // It uses generics to work with any model type (api.User, api.Server, etc.).
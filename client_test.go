@@ -2,7 +2,11 @@ package pterodactyl_test
 
 import (
 	"context"
+	stderrors "errors"
 	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/errors"
+	"net/http"
 	"testing"
 )
 
@@ -136,3 +140,105 @@ func TestNewClient_InvalidURL(t *testing.T) {
 		t.Errorf("expected an error from a request with a malformed baseURL, but got none")
 	}
 }
+
+// TestClient_Do_NonJSONError checks that an HTML error page from a proxy
+// still surfaces as an APIError carrying the status and body.
+func TestClient_Do_NonJSONError(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>502 Bad Gateway</html>"))
+	}))
+
+	err := getUser(c)
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.HTTPStatusCode != http.StatusBadGateway {
+		t.Errorf("expected status 502, got %d", apiErr.HTTPStatusCode)
+	}
+	if string(apiErr.RawBody) != "<html>502 Bad Gateway</html>" {
+		t.Errorf("unexpected raw body %q", apiErr.RawBody)
+	}
+}
+
+func TestClient_Do_ValidationError(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"ValidationException","status":"422","detail":"The email has already been taken.","meta":{"source_field":"email","rule":"unique"}}]}`))
+	}))
+
+	err := createUser(c)
+	var verr *errors.ValidationError
+	if !stderrors.As(err, &verr) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if rules := verr.Rules("email"); len(rules) != 1 || rules[0] != "unique" {
+		t.Errorf("unexpected rules %v", rules)
+	}
+}
+
+func TestClient_NewRequest_FilterAndSort(t *testing.T) {
+	t.Parallel()
+
+	c, err := pterodactyl.NewClient("https://panel.example.com", "ptla_test", pterodactyl.ApplicationKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		options  *api.PaginationOptions
+		expected map[string]string
+	}{
+		{
+			name:     "user by email",
+			options:  &api.PaginationOptions{Filter: api.UserFilter{Email: "alex@example.com"}},
+			expected: map[string]string{"filter[email]": "alex@example.com"},
+		},
+		{
+			name: "server filters and descending sort",
+			options: &api.PaginationOptions{
+				Page:   2,
+				Filter: api.ServerFilter{Name: "lobby", Image: "ghcr.io/pterodactyl/yolks:java_17"},
+				Sort:   []api.Sort{{Field: api.ServerSortID, Descending: true}, {Field: api.ServerSortUUID}},
+			},
+			expected: map[string]string{
+				"page":          "2",
+				"filter[name]":  "lobby",
+				"filter[image]": "ghcr.io/pterodactyl/yolks:java_17",
+				"sort":          "-id,uuid",
+			},
+		},
+		{
+			name:     "empty filter fields are skipped",
+			options:  &api.PaginationOptions{Filter: api.NodeFilter{FQDN: "node1.example.com"}, Sort: []api.Sort{{Field: api.NodeSortMemory}}},
+			expected: map[string]string{"filter[fqdn]": "node1.example.com", "sort": "memory"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := c.NewRequest(context.Background(), http.MethodGet, "/api/application/users", nil, tc.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			q := req.URL.Query()
+			if len(q) != len(tc.expected) {
+				t.Errorf("expected %d query parameters, got %v", len(tc.expected), q)
+			}
+			for k, v := range tc.expected {
+				if got := q.Get(k); got != v {
+					t.Errorf("%s: expected %q, got %q", k, v, got)
+				}
+			}
+		})
+	}
+}
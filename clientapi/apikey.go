@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/crud"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
 )
 
@@ -34,6 +35,20 @@ func (s *apiKeysService) List(ctx context.Context, options api.PaginationOptions
 	return results, &res.Meta, nil
 }
 
+// ListAll fetches every page of API keys.
+func (s *apiKeysService) ListAll(ctx context.Context) ([]*api.APIKey, error) {
+	return crud.ListAllFunc[api.APIKey](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.APIKey, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
+// Iter returns an iterator that fetches API keys one page at a time.
+func (s *apiKeysService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.APIKey] {
+	return crud.IterateFunc[api.APIKey](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.APIKey, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
 func (s *apiKeysService) Create(ctx context.Context, options api.APIKeyCreateOptions) (*api.APIKey, error) {
 	jsonBytes, err := json.Marshal(options)
 	if err != nil {
@@ -26,6 +26,20 @@ func (s *backupsService) List(ctx context.Context, options api.PaginationOptions
 	return crud.List[api.Backup](ctx, s.client, endpoint, &options)
 }
 
+// ListAll fetches every page of backups.
+func (s *backupsService) ListAll(ctx context.Context) ([]*api.Backup, error) {
+	return crud.ListAllFunc[api.Backup](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Backup, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
+// Iter returns an iterator that fetches backups one page at a time.
+func (s *backupsService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Backup] {
+	return crud.IterateFunc[api.Backup](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Backup, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
 // Create sends a request to begin a new backup creation process.
 func (s *backupsService) Create(ctx context.Context, options api.BackupCreateOptions) (*api.Backup, error) {
 	jsonBytes, err := json.Marshal(options)
@@ -4,18 +4,25 @@ import (
 	"context"
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/crud"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
 	"io"
+	"io/fs"
+	"regexp"
 )
 
 type APIKeysService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.APIKey, *api.Meta, error)
+	ListAll(ctx context.Context) ([]*api.APIKey, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.APIKey]
 	Create(ctx context.Context, options api.APIKeyCreateOptions) (*api.APIKey, error)
 	Delete(ctx context.Context, identifier string) error
 }
 
 type DatabasesService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.ClientDatabase, *api.Meta, error)
+	ListAll(ctx context.Context) ([]*api.ClientDatabase, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.ClientDatabase]
 	Create(ctx context.Context, options api.ClientDatabaseCreateOptions) (*api.ClientDatabase, error)
 	RotatePassword(ctx context.Context, databaseID string) (*api.ClientDatabase, error)
 	Delete(ctx context.Context, databaseID string) error
@@ -25,6 +32,8 @@ type FileService interface {
 	List(ctx context.Context, directory string) ([]*api.FileObject, error)
 	GetContents(ctx context.Context, filePath string) (string, error)
 	Download(ctx context.Context, filePath string) (*api.SignedURL, error)
+	Open(ctx context.Context, filePath string) (io.ReadCloser, error)
+	DownloadTo(ctx context.Context, filePath string, w io.Writer, options api.DownloadOptions) (int64, error)
 	Rename(ctx context.Context, options api.RenameFilesOptions) error
 	Copy(ctx context.Context, options api.CopyFileOptions) error
 	Write(ctx context.Context, filePath string, content io.Reader) error
@@ -33,10 +42,28 @@ type FileService interface {
 	Delete(ctx context.Context, options api.DeleteFilesOptions) error
 	CreateFolder(ctx context.Context, options api.CreateFolderOptions) error
 	GetUploadURL(ctx context.Context) (*api.SignedURL, error)
+	Upload(ctx context.Context, directory, name string, content io.Reader) error
+	UploadFiles(ctx context.Context, directory string, files []api.UploadFile, options api.UploadOptions) error
+	SyncDir(ctx context.Context, localDir, remoteDir string, options api.SyncOptions) ([]api.SyncChange, error)
+	FS(ctx context.Context, options api.FSOptions) ServerFS
+	Walk(ctx context.Context, root string, options api.WalkOptions, fn func(filePath string, file *api.FileObject) error) error
+	Find(ctx context.Context, root string, options api.FindOptions) ([]api.FoundFile, error)
+	Grep(ctx context.Context, root string, pattern *regexp.Regexp, options api.GrepOptions) ([]api.GrepMatch, error)
+}
+
+// ServerFS is a read-only view of a server's files returned by
+// FileService.FS.
+type ServerFS interface {
+	fs.ReadDirFS
+	fs.StatFS
+	// ClearCache drops the cached directory listings.
+	ClearCache()
 }
 
 type ScheduleService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.Schedule, *api.Meta, error)
+	ListAll(ctx context.Context) ([]*api.Schedule, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Schedule]
 	Create(ctx context.Context, options api.ScheduleCreateOptions) (*api.Schedule, error)
 	Details(ctx context.Context, scheduleID int) (*api.Schedule, error)
 	Update(ctx context.Context, scheduleID int, options api.ScheduleUpdateOptions) (*api.Schedule, error)
@@ -48,6 +75,8 @@ type ScheduleService interface {
 
 type NetworkService interface {
 	ListAllocations(ctx context.Context, options api.PaginationOptions) ([]*api.Allocation, *api.Meta, error)
+	ListAllAllocations(ctx context.Context) ([]*api.Allocation, error)
+	IterAllocations(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Allocation]
 	AssignAllocation(ctx context.Context) (*api.Allocation, error)
 	SetAllocationNote(ctx context.Context, allocationID int, options api.AllocationNoteOptions) (*api.Allocation, error)
 	SetPrimaryAllocation(ctx context.Context, allocationID int) (*api.Allocation, error)
@@ -56,6 +85,8 @@ type NetworkService interface {
 
 type UsersService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.Subuser, *api.Meta, error)
+	ListAll(ctx context.Context) ([]*api.Subuser, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Subuser]
 	Create(ctx context.Context, options api.SubuserCreateOptions) (*api.Subuser, error)
 	Details(ctx context.Context, uuid string) (*api.Subuser, error)
 	Update(ctx context.Context, uuid string, options api.SubuserUpdateOptions) (*api.Subuser, error)
@@ -64,6 +95,8 @@ type UsersService interface {
 
 type BackupService interface {
 	List(ctx context.Context, options api.PaginationOptions) ([]*api.Backup, *api.Meta, error)
+	ListAll(ctx context.Context) ([]*api.Backup, error)
+	Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Backup]
 	Create(ctx context.Context, options api.BackupCreateOptions) (*api.Backup, error)
 	Details(ctx context.Context, uuid string) (*api.Backup, error)
 	Download(ctx context.Context, uuid string) (*api.BackupDownload, error)
@@ -72,6 +105,8 @@ type BackupService interface {
 
 type StartupService interface {
 	ListVariables(ctx context.Context, options api.PaginationOptions) ([]*api.StartupVariable, *api.Meta, error)
+	ListAllVariables(ctx context.Context) ([]*api.StartupVariable, error)
+	IterVariables(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.StartupVariable]
 	UpdateVariable(ctx context.Context, options api.UpdateVariableOptions) (*api.StartupVariable, error)
 }
 
@@ -85,7 +120,7 @@ type ServersService interface {
 	GetWebsocket(ctx context.Context) (*api.WebsocketDetails, error)
 	GetResources(ctx context.Context) (*api.Resources, error)
 	SendCommand(ctx context.Context, command string) error
-	SetPowerState(ctx context.Context, signal string) error
+	SetPowerState(ctx context.Context, signal api.PowerSignal) error
 
 	Databases() DatabasesService
 	Files() FileService
@@ -109,6 +144,8 @@ type AccountService interface {
 
 type ClientAPI interface {
 	ListServers(ctx context.Context, options api.PaginationOptions) ([]*api.ClientServer, *api.Meta, error)
+	ListAllServers(ctx context.Context) ([]*api.ClientServer, error)
+	IterServers(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.ClientServer]
 	ListPermissions(ctx context.Context) (*api.Permission, error)
 
 	Servers(identifier string) ServersService
@@ -142,6 +179,20 @@ func (s *ClientAPIService) ListServers(ctx context.Context, options api.Paginati
 	return results, &response.Meta, nil
 }
 
+// ListAllServers fetches every page of servers.
+func (s *ClientAPIService) ListAllServers(ctx context.Context) ([]*api.ClientServer, error) {
+	return crud.ListAllFunc[api.ClientServer](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.ClientServer, *api.Meta, error) {
+		return s.ListServers(ctx, *o)
+	})
+}
+
+// IterServers returns an iterator that fetches servers one page at a time.
+func (s *ClientAPIService) IterServers(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.ClientServer] {
+	return crud.IterateFunc[api.ClientServer](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.ClientServer, *api.Meta, error) {
+		return s.ListServers(ctx, *o)
+	})
+}
+
 func (s *ClientAPIService) ListPermissions(ctx context.Context) (*api.Permission, error) {
 	req, err := s.client.NewRequest(ctx, "GET", "/api/client/permissions", nil, nil)
 	if err != nil {
@@ -24,6 +24,20 @@ func (s *databasesService) List(ctx context.Context, options api.PaginationOptio
 	return crud.List[api.ClientDatabase](ctx, s.client, endpoint, &options)
 }
 
+// ListAll fetches every page of databases.
+func (s *databasesService) ListAll(ctx context.Context) ([]*api.ClientDatabase, error) {
+	return crud.ListAllFunc[api.ClientDatabase](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.ClientDatabase, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
+// Iter returns an iterator that fetches databases one page at a time.
+func (s *databasesService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.ClientDatabase] {
+	return crud.IterateFunc[api.ClientDatabase](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.ClientDatabase, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
 func processCreateOrRotateResponse(res *api.ClientDatabaseCreateResponse) *api.ClientDatabase {
 	db := res.Attributes
 	if res.Relationships != nil && res.Relationships.Password != nil && res.Relationships.Password.Attributes != nil {
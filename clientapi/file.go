@@ -48,8 +48,9 @@ func (s *filesService) GetContents(ctx context.Context, filePath string) (string
 		return "", fmt.Errorf("failed to create get contents request: %w", err)
 	}
 
-	// This endpoint returns raw text, not JSON.
-	httpRes, err := s.client.Do(ctx, req, nil)
+	// This endpoint returns raw text, not JSON, so the body has to stay open
+	// after the request returns.
+	httpRes, err := s.client.Stream(ctx, req)
 	if err != nil {
 		return "", err
 	}
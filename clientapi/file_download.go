@@ -0,0 +1,143 @@
+package clientapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// Open streams the contents of a file from Wings through a signed download
+// URL. Unlike GetContents, which the panel limits to small text files, it
+// works for files of any size. The caller must close the reader.
+func (s *filesService) Open(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	res, err := s.openAt(ctx, filePath, 0)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// DownloadTo copies a file to w, resuming from where it stopped if the
+// transfer is interrupted. It returns the number of bytes written to w.
+//
+// Signed URLs can only be used once, so every resumption asks the panel for a
+// new one. Wings answers range requests with the whole file, in which case
+// the bytes already written are skipped.
+func (s *filesService) DownloadTo(ctx context.Context, filePath string, w io.Writer, options api.DownloadOptions) (int64, error) {
+	resumes := options.MaxResumes
+	if resumes == 0 {
+		resumes = api.DefaultDownloadResumes
+	}
+
+	offset := options.Offset
+	for attempt := 0; ; attempt++ {
+		res, err := s.openAt(ctx, filePath, offset)
+		if err != nil {
+			return offset - options.Offset, err
+		}
+		n, complete, err := copyBody(res, w, offset, options.Progress)
+		res.Body.Close()
+		offset += n
+		if complete {
+			return offset - options.Offset, nil
+		}
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		if _, ok := err.(*writeError); ok || ctx.Err() != nil || attempt >= resumes {
+			return offset - options.Offset, fmt.Errorf("failed to download %s: %w", filePath, err)
+		}
+	}
+}
+
+// openAt requests a signed URL for filePath and opens it at offset.
+func (s *filesService) openAt(ctx context.Context, filePath string, offset int64) (*http.Response, error) {
+	signed, err := s.Download(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewRequest(ctx, "GET", signed.URL, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return s.client.Stream(ctx, req)
+}
+
+// writeError marks a failure to write to the destination, which resuming
+// cannot fix.
+type writeError struct{ err error }
+
+func (e *writeError) Error() string { return e.err.Error() }
+func (e *writeError) Unwrap() error { return e.err }
+
+// copyBody writes the part of res from offset onwards to w and reports
+// whether the end of the file was reached. A response to a range request
+// starts at offset; a full response has the first offset bytes skipped.
+func copyBody(res *http.Response, w io.Writer, offset int64, progress func(downloaded, total int64)) (int64, bool, error) {
+	total := res.ContentLength
+	if res.StatusCode == http.StatusPartialContent {
+		start, size, ok := parseContentRange(res.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return 0, false, fmt.Errorf("unexpected Content-Range %q for offset %d", res.Header.Get("Content-Range"), offset)
+		}
+		total = size
+	} else if offset > 0 {
+		if _, err := io.CopyN(io.Discard, res.Body, offset); err != nil {
+			return 0, false, err
+		}
+	}
+
+	var written int64
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := res.Body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return written, false, &writeError{werr}
+			}
+			written += int64(n)
+			if progress != nil {
+				progress(offset+written, total)
+			}
+		}
+		if err == io.EOF {
+			return written, total < 0 || offset+written >= total, nil
+		}
+		if err != nil {
+			return written, false, err
+		}
+	}
+}
+
+// parseContentRange parses "bytes start-end/size", returning -1 for an
+// unknown size.
+func parseContentRange(h string) (start, size int64, ok bool) {
+	if !strings.HasPrefix(h, "bytes ") {
+		return 0, 0, false
+	}
+	rng, total, found := strings.Cut(strings.TrimPrefix(h, "bytes "), "/")
+	if !found {
+		return 0, 0, false
+	}
+	first, _, found := strings.Cut(rng, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if total == "*" {
+		return start, -1, true
+	}
+	size, err = strconv.ParseInt(total, 10, 64)
+	return start, size, err == nil
+}
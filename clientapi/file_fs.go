@@ -0,0 +1,234 @@
+package clientapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// FS returns a read-only file system over the server's files, for use with
+// fs.WalkDir, fs.Glob, template.ParseFS and the like. Directories are read
+// with List and files with GetContents, so the panel's limits on files it
+// can edit also apply to reads. Every request is made with ctx.
+func (s *filesService) FS(ctx context.Context, options api.FSOptions) ServerFS {
+	root := options.Root
+	if root == "" {
+		root = "/"
+	}
+	return &serverFS{ctx: ctx, files: s, root: root, ttl: options.CacheTTL}
+}
+
+type serverFS struct {
+	ctx   context.Context
+	files *filesService
+	root  string
+	ttl   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedListing
+}
+
+type cachedListing struct {
+	files  []*api.FileObject
+	listed time.Time
+}
+
+// list returns the entries of a directory sorted by name.
+func (f *serverFS) list(name string) ([]*api.FileObject, error) {
+	dir := path.Join(f.root, name)
+	if f.ttl > 0 {
+		f.mu.Lock()
+		c, ok := f.cache[dir]
+		f.mu.Unlock()
+		if ok && now().Sub(c.listed) < f.ttl {
+			return c.files, nil
+		}
+	}
+
+	listed := now()
+	files, err := f.files.List(f.ctx, dir)
+	if err != nil {
+		if pterrors.IsNotFound(err) {
+			return nil, fs.ErrNotExist
+		}
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	if f.ttl > 0 {
+		f.mu.Lock()
+		if f.cache == nil {
+			f.cache = make(map[string]cachedListing)
+		}
+		f.cache[dir] = cachedListing{files: files, listed: listed}
+		f.mu.Unlock()
+	}
+	return files, nil
+}
+
+func (f *serverFS) ClearCache() {
+	f.mu.Lock()
+	f.cache = nil
+	f.mu.Unlock()
+}
+
+func (f *serverFS) stat(name string) (fs.FileInfo, error) {
+	if name == "." {
+		return rootInfo{}, nil
+	}
+	files, err := f.list(path.Dir(name))
+	if err != nil {
+		return nil, err
+	}
+	base := path.Base(name)
+	i := sort.Search(len(files), func(i int) bool { return files[i].Name >= base })
+	if i == len(files) || files[i].Name != base {
+		return nil, fs.ErrNotExist
+	}
+	return files[i].FileInfo(), nil
+}
+
+func (f *serverFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	info, err := f.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+func (f *serverFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	files, err := f.list(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	entries := make([]fs.DirEntry, len(files))
+	for i, file := range files {
+		entries[i] = fs.FileInfoToDirEntry(file.FileInfo())
+	}
+	return entries, nil
+}
+
+// Open stats name but does not read it: directories are listed on the first
+// ReadDir and file contents are fetched on the first Read.
+func (f *serverFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	info, err := f.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if info.IsDir() {
+		return &serverDir{fs: f, name: name, info: info}, nil
+	}
+	return &serverFile{fs: f, name: name, info: info}, nil
+}
+
+// rootInfo describes the root of a serverFS, which has no listing of its own.
+type rootInfo struct{}
+
+func (rootInfo) Name() string       { return "." }
+func (rootInfo) Size() int64        { return 0 }
+func (rootInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o755 }
+func (rootInfo) ModTime() time.Time { return time.Time{} }
+func (rootInfo) IsDir() bool        { return true }
+func (rootInfo) Sys() any           { return nil }
+
+type serverFile struct {
+	fs      *serverFS
+	name    string
+	info    fs.FileInfo
+	content *strings.Reader
+	closed  bool
+}
+
+func (f *serverFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *serverFile) Read(b []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.content == nil {
+		content, err := f.fs.files.GetContents(f.fs.ctx, path.Join(f.fs.root, f.name))
+		if err != nil {
+			return 0, &fs.PathError{Op: "read", Path: f.name, Err: err}
+		}
+		f.content = strings.NewReader(content)
+	}
+	return f.content.Read(b)
+}
+
+func (f *serverFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return nil
+}
+
+type serverDir struct {
+	fs      *serverFS
+	name    string
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	read    bool
+	closed  bool
+}
+
+func (d *serverDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *serverDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir returns the next n entries, or all remaining ones if n <= 0, as
+// fs.ReadDirFile requires.
+func (d *serverDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.closed {
+		return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: fs.ErrClosed}
+	}
+	if !d.read {
+		entries, err := d.fs.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.read = entries, true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+func (d *serverDir) Close() error {
+	if d.closed {
+		return &fs.PathError{Op: "close", Path: d.name, Err: fs.ErrClosed}
+	}
+	d.closed = true
+	return nil
+}
@@ -0,0 +1,248 @@
+package clientapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// Walk calls fn for every entry below root in lexical order, descending into
+// directories after fn has seen them, like fs.WalkDir. Returning fs.SkipDir
+// from fn skips a directory, or the rest of the current directory if
+// returned for a file; any other error stops the walk and is returned.
+// Symlinks are not followed.
+func (s *filesService) Walk(ctx context.Context, root string, options api.WalkOptions, fn func(filePath string, file *api.FileObject) error) error {
+	if err := checkPatterns(options.Skip); err != nil {
+		return err
+	}
+	return s.walk(ctx, root, ".", 1, options, fn)
+}
+
+func (s *filesService) walk(ctx context.Context, root, rel string, depth int, options api.WalkOptions, fn func(string, *api.FileObject) error) error {
+	dir := path.Join(root, rel)
+	files, err := s.List(ctx, dir)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	for _, f := range files {
+		p := path.Join(rel, f.Name)
+		if excluded(options.Skip, p) {
+			continue
+		}
+		err := fn(path.Join(root, p), f)
+		if errors.Is(err, fs.SkipDir) {
+			if isDir(f) {
+				continue
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if isDir(f) && (options.MaxDepth <= 0 || depth < options.MaxDepth) {
+			if err := s.walk(ctx, root, p, depth+1, options, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isDir(f *api.FileObject) bool {
+	return !f.IsFile && !f.IsSymlink
+}
+
+// Find returns the entries below root that pass every filter in options,
+// sorted by path. Directories are listed concurrently.
+func (s *filesService) Find(ctx context.Context, root string, options api.FindOptions) ([]api.FoundFile, error) {
+	patterns := options.Skip
+	if options.Name != "" {
+		patterns = append([]string{options.Name}, patterns...)
+	}
+	if err := checkPatterns(patterns); err != nil {
+		return nil, err
+	}
+
+	var found []api.FoundFile
+	err := s.listTree(ctx, root, options, func(rel string, f *api.FileObject) {
+		if matches(options, rel, f) {
+			found = append(found, api.FoundFile{Path: path.Join(root, rel), FileObject: f})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Path < found[j].Path })
+	return found, nil
+}
+
+func matches(options api.FindOptions, rel string, f *api.FileObject) bool {
+	switch {
+	case isDir(f) && !options.IncludeDirs:
+		return false
+	case options.Name != "" && !matchPath(options.Name, rel):
+		return false
+	case options.MinSize > 0 && f.Size < options.MinSize:
+		return false
+	case options.MaxSize > 0 && f.Size > options.MaxSize:
+		return false
+	case !options.ModifiedAfter.IsZero() && !f.ModifiedAt.After(options.ModifiedAfter):
+		return false
+	case !options.ModifiedBefore.IsZero() && !f.ModifiedAt.Before(options.ModifiedBefore):
+		return false
+	}
+	return true
+}
+
+func concurrency(n int) int {
+	if n <= 0 {
+		return api.DefaultSearchConcurrency
+	}
+	return n
+}
+
+// listTree lists root recursively with up to options.Concurrency requests in
+// flight and calls visit, one call at a time, for every entry not skipped.
+// The first failure cancels the remaining requests.
+func (s *filesService) listTree(ctx context.Context, root string, options api.FindOptions, visit func(rel string, f *api.FileObject)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency(options.Concurrency))
+	)
+	var list func(rel string, depth int)
+	list = func(rel string, depth int) {
+		defer wg.Done()
+		dir := path.Join(root, rel)
+		sem <- struct{}{}
+		files, err := s.List(ctx, dir)
+		<-sem
+
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr != nil {
+			return
+		}
+		if err != nil {
+			firstErr = fmt.Errorf("failed to list %s: %w", dir, err)
+			cancel()
+			return
+		}
+		for _, f := range files {
+			p := path.Join(rel, f.Name)
+			if excluded(options.Skip, p) {
+				continue
+			}
+			visit(p, f)
+			if isDir(f) && (options.MaxDepth <= 0 || depth < options.MaxDepth) {
+				wg.Add(1)
+				go list(p, depth+1)
+			}
+		}
+	}
+
+	wg.Add(1)
+	go list(".", 1)
+	wg.Wait()
+	return firstErr
+}
+
+// Grep searches the files Find selects for lines matching pattern, reading
+// up to options.Concurrency files at once with GetContents. Symlinks, files
+// larger than options.MaxFileSize and files that look binary are skipped.
+// Matches are sorted by path and line.
+//
+// A file that cannot be read does not stop the search: the matches in the
+// other files are returned with an *api.FileErrors listing the failures.
+func (s *filesService) Grep(ctx context.Context, root string, pattern *regexp.Regexp, options api.GrepOptions) ([]api.GrepMatch, error) {
+	maxSize := options.MaxFileSize
+	if maxSize <= 0 {
+		maxSize = api.DefaultGrepMaxFileSize
+	}
+	findOptions := options.FindOptions
+	findOptions.IncludeDirs = false
+	if findOptions.MaxSize <= 0 || findOptions.MaxSize > maxSize {
+		findOptions.MaxSize = maxSize
+	}
+	files, err := s.Find(ctx, root, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		matches []api.GrepMatch
+		failed  = make(map[string]error)
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency(options.Concurrency))
+	)
+	for _, f := range files {
+		if f.IsSymlink {
+			continue
+		}
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(filePath string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			content, err := s.GetContents(ctx, filePath)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[filePath] = err
+				return
+			}
+			matches = append(matches, grepContent(filePath, content, pattern)...)
+		}(f.Path)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Path != matches[j].Path {
+			return matches[i].Path < matches[j].Path
+		}
+		return matches[i].Line < matches[j].Line
+	})
+	if len(failed) > 0 {
+		return matches, &api.FileErrors{Errors: failed}
+	}
+	return matches, nil
+}
+
+// grepContent returns the lines of content matching pattern, or nothing if
+// content contains a NUL byte and so is not text.
+func grepContent(filePath, content string, pattern *regexp.Regexp) []api.GrepMatch {
+	if strings.IndexByte(content, 0) >= 0 {
+		return nil
+	}
+	var matches []api.GrepMatch
+	for i, text := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		text = strings.TrimSuffix(text, "\r")
+		if pattern.MatchString(text) {
+			matches = append(matches, api.GrepMatch{Path: filePath, Line: i + 1, Text: text})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,337 @@
+package clientapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// SyncDir makes remoteDir match localDir, or localDir match remoteDir when
+// pulling, and returns the changes made. Files are copied when they are
+// missing from the destination, differ in size, or are newer in the source;
+// Wings sets the modification time of uploaded files to the time of the
+// upload, so comparing for equality would copy every file on every push.
+// Pulled files get the server's modification time.
+//
+// Deletions come first, then new directories, then copies, each in path
+// order. If a change fails, the changes already made are returned with the
+// error.
+func (s *filesService) SyncDir(ctx context.Context, localDir, remoteDir string, options api.SyncOptions) ([]api.SyncChange, error) {
+	if err := checkPatterns(options.Exclude); err != nil {
+		return nil, err
+	}
+
+	sy := &syncer{files: s, localDir: localDir, remoteDir: remoteDir, options: options}
+	var err error
+	if sy.local, err = localTree(localDir, options.Exclude); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", localDir, err)
+	}
+	if sy.remote, err = s.remoteTree(ctx, remoteDir, options.Exclude); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", remoteDir, err)
+	}
+
+	changes, err := sy.plan(ctx)
+	if err != nil || options.DryRun {
+		return changes, err
+	}
+	if options.Direction == api.SyncPush && options.MaxSize > 0 {
+		for _, c := range changes {
+			if c.Action == api.SyncCopy && c.Size > options.MaxSize {
+				return nil, &api.UploadTooLargeError{Name: c.Path, Size: c.Size, Limit: options.MaxSize}
+			}
+		}
+	}
+	for i, c := range changes {
+		if err := sy.apply(ctx, c); err != nil {
+			return changes[:i], fmt.Errorf("failed to %s %s: %w", c.Action, c.Path, err)
+		}
+	}
+	return changes, nil
+}
+
+// syncEntry is a file or directory found on one side of a sync.
+type syncEntry struct {
+	dir     bool
+	size    int64
+	modTime time.Time
+}
+
+// syncTree holds the entries below a synced directory by slash-separated
+// relative path. The directory itself is "." and is missing if it does not
+// exist.
+type syncTree map[string]syncEntry
+
+func (t syncTree) paths() []string {
+	paths := make([]string, 0, len(t))
+	for p := range t {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// excluded reports whether rel matches one of the exclude patterns.
+func excluded(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if matchPath(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchPath matches a pattern without a slash against the last element of
+// rel and any other pattern against the whole of rel.
+func matchPath(pattern, rel string) bool {
+	name := rel
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(rel)
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// localTree walks a local directory. Symlinks and special files are skipped.
+func localTree(root string, exclude []string) (syncTree, error) {
+	tree := make(syncTree)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && excluded(exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		tree[rel] = syncEntry{dir: d.IsDir(), size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return tree, err
+}
+
+// remoteTree lists a server directory recursively, one request per
+// directory. Symlinks are skipped.
+func (s *filesService) remoteTree(ctx context.Context, root string, exclude []string) (syncTree, error) {
+	tree := make(syncTree)
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		files, err := s.List(ctx, path.Join(root, rel))
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			p := path.Join(rel, f.Name)
+			if f.IsSymlink || excluded(exclude, p) {
+				continue
+			}
+			tree[p] = syncEntry{dir: !f.IsFile, size: f.Size, modTime: f.ModifiedAt}
+			if !f.IsFile {
+				if err := walk(p); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := walk("."); err != nil {
+		if pterrors.IsNotFound(err) && len(tree) == 0 {
+			return tree, nil
+		}
+		return nil, err
+	}
+	tree["."] = syncEntry{dir: true}
+	return tree, nil
+}
+
+type syncer struct {
+	files     *filesService
+	localDir  string
+	remoteDir string
+	options   api.SyncOptions
+
+	local, remote syncTree
+	upload        uploadURL
+}
+
+// plan compares the trees. An entry whose type differs between the sides is
+// deleted from the destination and copied again, whatever options.Delete
+// says.
+func (sy *syncer) plan(ctx context.Context) ([]api.SyncChange, error) {
+	src, dst := sy.local, sy.remote
+	if sy.options.Direction == api.SyncPull {
+		src, dst = sy.remote, sy.local
+	}
+	if _, ok := src["."]; !ok {
+		return nil, fmt.Errorf("source directory does not exist")
+	}
+
+	var deletes, mkdirs, copies []api.SyncChange
+	for _, p := range src.paths() {
+		se := src[p]
+		de, ok := dst[p]
+		if ok && de.dir != se.dir {
+			deletes = append(deletes, api.SyncChange{Action: api.SyncDelete, Path: p})
+			ok = false
+		}
+		if se.dir {
+			if !ok {
+				mkdirs = append(mkdirs, api.SyncChange{Action: api.SyncMkdir, Path: p})
+			}
+			continue
+		}
+
+		changed := !ok || se.size != de.size
+		if !changed && sy.options.Checksum {
+			same, err := sy.sameContent(ctx, p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare %s: %w", p, err)
+			}
+			changed = !same
+		} else if !changed {
+			changed = se.modTime.Truncate(time.Second).After(de.modTime.Truncate(time.Second))
+		}
+		if changed {
+			copies = append(copies, api.SyncChange{Action: api.SyncCopy, Path: p, Size: se.size})
+		}
+	}
+
+	if sy.options.Delete {
+		for _, p := range dst.paths() {
+			if _, ok := src[p]; ok {
+				continue
+			}
+			// Entries of a deleted directory go with it.
+			if parent, ok := src[path.Dir(p)]; !ok || !parent.dir {
+				continue
+			}
+			deletes = append(deletes, api.SyncChange{Action: api.SyncDelete, Path: p})
+		}
+		sort.Slice(deletes, func(i, j int) bool { return deletes[i].Path < deletes[j].Path })
+	}
+
+	return append(append(deletes, mkdirs...), copies...), nil
+}
+
+func (sy *syncer) localPath(p string) string {
+	return filepath.Join(sy.localDir, filepath.FromSlash(p))
+}
+
+func (sy *syncer) remotePath(p string) string {
+	return path.Join(sy.remoteDir, p)
+}
+
+// sameContent compares the SHA-256 of a file on both sides.
+func (sy *syncer) sameContent(ctx context.Context, p string) (bool, error) {
+	f, err := os.Open(sy.localPath(p))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	local := sha256.New()
+	if _, err := io.Copy(local, f); err != nil {
+		return false, err
+	}
+
+	remote := sha256.New()
+	if _, err := sy.files.DownloadTo(ctx, sy.remotePath(p), remote, api.DownloadOptions{}); err != nil {
+		return false, err
+	}
+	return string(local.Sum(nil)) == string(remote.Sum(nil)), nil
+}
+
+func (sy *syncer) apply(ctx context.Context, c api.SyncChange) error {
+	if sy.options.Direction == api.SyncPull {
+		return sy.applyLocal(ctx, c)
+	}
+	return sy.applyRemote(ctx, c)
+}
+
+func (sy *syncer) applyRemote(ctx context.Context, c api.SyncChange) error {
+	full := sy.remotePath(c.Path)
+	switch c.Action {
+	case api.SyncDelete:
+		return sy.files.Delete(ctx, api.DeleteFilesOptions{Root: path.Dir(full), Files: []string{path.Base(full)}})
+	case api.SyncMkdir:
+		return sy.files.CreateFolder(ctx, api.CreateFolderOptions{Root: path.Dir(full), Name: path.Base(full)})
+	}
+
+	f, err := os.Open(sy.localPath(c.Path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	file := api.UploadFile{Name: path.Base(full), Content: f, Size: c.Size}
+	return sy.files.uploadFile(ctx, &sy.upload, path.Dir(full), file, c.Size, api.UploadOptions{MaxSize: sy.options.MaxSize})
+}
+
+func (sy *syncer) applyLocal(ctx context.Context, c api.SyncChange) error {
+	full := sy.localPath(c.Path)
+	switch c.Action {
+	case api.SyncDelete:
+		return os.RemoveAll(full)
+	case api.SyncMkdir:
+		return os.MkdirAll(full, 0o755)
+	}
+
+	// Download next to the destination so a failed transfer never leaves a
+	// partial file in its place.
+	tmp, err := os.CreateTemp(filepath.Dir(full), ".sync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := sy.files.DownloadTo(ctx, sy.remotePath(c.Path), tmp, api.DownloadOptions{}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), full); err != nil {
+		return err
+	}
+	modTime := sy.remote[c.Path].modTime
+	return os.Chtimes(full, modTime, modTime)
+}
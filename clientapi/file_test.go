@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -303,3 +306,141 @@ func TestFilesService_Error(t *testing.T) {
 		t.Errorf("expected error %v, got %v", apiErr, err)
 	}
 }
+
+// uploadedFiles decodes the multipart body of an upload request.
+func uploadedFiles(t *testing.T, body []byte) map[string]string {
+	t.Helper()
+	boundary, _, _ := strings.Cut(strings.TrimPrefix(string(body), "--"), "\r\n")
+	r := multipart.NewReader(bytes.NewReader(body), boundary)
+	files := make(map[string]string)
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("invalid multipart body: %v", err)
+		}
+		if part.FormName() != "files" {
+			t.Errorf("expected form field files, got %q", part.FormName())
+		}
+		content, _ := io.ReadAll(part)
+		files[part.FileName()] = string(content)
+	}
+}
+
+func TestFilesService_UploadFiles(t *testing.T) {
+	signedURL := func(token string) []byte {
+		return []byte(`{"object":"signed_url","attributes":{"url":"https://node.example.com:8080/upload/file?token=` + token + `"}}`)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+			{StatusCode: http.StatusOK, Body: signedURL("a")},
+			{StatusCode: http.StatusOK},
+			{StatusCode: http.StatusOK},
+		}}
+		s := newFilesService(mock, testServerIdentifier)
+
+		var progress []string
+		err := s.UploadFiles(context.Background(), "/plugins", []api.UploadFile{
+			{Name: "a.jar", Content: strings.NewReader("first")},
+			{Name: "b.jar", Content: io.MultiReader(strings.NewReader("second"))},
+		}, api.UploadOptions{
+			MaxSize: 10,
+			Progress: func(name string, uploaded, total int64) {
+				progress = append(progress, fmt.Sprintf("%s %d/%d", name, uploaded, total))
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.Requests) != 3 {
+			t.Fatalf("expected the signed URL to be reused for the batch, got %d requests", len(mock.Requests))
+		}
+		for i, want := range []map[string]string{{"a.jar": "first"}, {"b.jar": "second"}} {
+			req := mock.Requests[i+1]
+			if req.Method != "POST" || req.Endpoint != "https://node.example.com:8080/upload/file?directory=%2Fplugins&token=a" {
+				t.Errorf("unexpected upload request %s %s", req.Method, req.Endpoint)
+			}
+			if got := uploadedFiles(t, req.Body); !reflect.DeepEqual(got, want) {
+				t.Errorf("expected files %v, got %v", want, got)
+			}
+		}
+		if want := []string{"a.jar 5/5", "b.jar 6/-1"}; !reflect.DeepEqual(progress, want) {
+			t.Errorf("expected progress %v, got %v", want, progress)
+		}
+	})
+
+	t.Run("refreshes an aging URL", func(t *testing.T) {
+		clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		now = func() time.Time { return clock }
+		defer func() { now = time.Now }()
+
+		mock := &testutil.MockRequester{}
+		mock.Responses = []testutil.MockResponse{
+			{StatusCode: http.StatusOK, Body: signedURL("a")},
+			{StatusCode: http.StatusOK},
+			{StatusCode: http.StatusOK, Body: signedURL("b")},
+			{StatusCode: http.StatusOK},
+		}
+		s := newFilesService(mock, testServerIdentifier)
+		slow := readerFunc(func(p []byte) (int, error) {
+			clock = clock.Add(20 * time.Minute)
+			return 0, io.EOF
+		})
+		err := s.UploadFiles(context.Background(), "/", []api.UploadFile{
+			{Name: "slow", Content: slow},
+			{Name: "next", Content: strings.NewReader("x")},
+		}, api.UploadOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasSuffix(mock.Requests[3].Endpoint, "token=b") {
+			t.Errorf("expected the second file to use a new URL, got %s", mock.Requests[3].Endpoint)
+		}
+	})
+
+	t.Run("rejects large files up front", func(t *testing.T) {
+		mock := &testutil.MockRequester{}
+		s := newFilesService(mock, testServerIdentifier)
+		err := s.UploadFiles(context.Background(), "/", []api.UploadFile{
+			{Name: "small", Content: strings.NewReader("ok")},
+			{Name: "world.zip", Content: bytes.NewReader(make([]byte, 2048))},
+		}, api.UploadOptions{MaxSize: 1024})
+
+		var tooLarge *api.UploadTooLargeError
+		if !stderrors.As(err, &tooLarge) || tooLarge.Name != "world.zip" || tooLarge.Size != 2048 {
+			t.Fatalf("expected an UploadTooLargeError for world.zip, got %v", err)
+		}
+		if len(mock.Requests) != 0 {
+			t.Errorf("expected nothing to be uploaded, got %d requests", len(mock.Requests))
+		}
+	})
+
+	t.Run("cuts off files of unknown size", func(t *testing.T) {
+		mock := &testutil.MockRequester{Responses: []testutil.MockResponse{
+			{StatusCode: http.StatusOK, Body: signedURL("a")},
+			{StatusCode: http.StatusOK},
+			{StatusCode: http.StatusOK, Body: signedURL("b")},
+			{StatusCode: http.StatusBadRequest},
+		}}
+		s := newFilesService(mock, testServerIdentifier)
+		err := s.Upload(context.Background(), "/", "ok", strings.NewReader("fine"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = s.UploadFiles(context.Background(), "/", []api.UploadFile{
+			{Name: "stream", Content: io.MultiReader(bytes.NewReader(make([]byte, 2048)))},
+		}, api.UploadOptions{MaxSize: 1024})
+		var tooLarge *api.UploadTooLargeError
+		if !stderrors.As(err, &tooLarge) || tooLarge.Size != 1025 {
+			t.Fatalf("expected an UploadTooLargeError, got %v", err)
+		}
+	})
+}
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
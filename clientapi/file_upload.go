@@ -0,0 +1,212 @@
+package clientapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// uploadURLLifetime is how long a signed upload URL is reused. The panel
+// signs them for 15 minutes; the margin covers clock skew and the time an
+// upload takes to reach Wings.
+const uploadURLLifetime = 14 * time.Minute
+
+// now is replaced in tests.
+var now = time.Now
+
+// Upload uploads content to directory as name through a signed Wings URL.
+// Use UploadFiles for progress reporting and size limits.
+func (s *filesService) Upload(ctx context.Context, directory, name string, content io.Reader) error {
+	return s.UploadFiles(ctx, directory, []api.UploadFile{{Name: name, Content: content}}, api.UploadOptions{})
+}
+
+// UploadFiles uploads files to directory one after another, streaming each
+// as a multipart request to a signed Wings URL. The URL is shared by the
+// batch and replaced when it is about to expire, or when Wings rejects it and
+// the file can be rewound.
+//
+// Files larger than options.MaxSize are reported as *api.UploadTooLargeError
+// before anything is uploaded. The first failure stops the batch; files
+// before it stay uploaded.
+func (s *filesService) UploadFiles(ctx context.Context, directory string, files []api.UploadFile, options api.UploadOptions) error {
+	sizes := make([]int64, len(files))
+	for i, f := range files {
+		sizes[i] = uploadSize(f)
+		if options.MaxSize > 0 && sizes[i] > options.MaxSize {
+			return &api.UploadTooLargeError{Name: f.Name, Size: sizes[i], Limit: options.MaxSize}
+		}
+	}
+
+	signed := &uploadURL{}
+	for i, f := range files {
+		if err := s.uploadFile(ctx, signed, directory, f, sizes[i], options); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// uploadURL is a signed upload URL and the time it was issued. Unlike
+// download URLs, upload URLs can be used until they expire.
+type uploadURL struct {
+	url    string
+	issued time.Time
+}
+
+func (s *filesService) refreshUploadURL(ctx context.Context, u *uploadURL) error {
+	if u.url != "" && now().Sub(u.issued) < uploadURLLifetime {
+		return nil
+	}
+	issued := now()
+	signed, err := s.GetUploadURL(ctx)
+	if err != nil {
+		return err
+	}
+	u.url, u.issued = signed.URL, issued
+	return nil
+}
+
+func (s *filesService) uploadFile(ctx context.Context, u *uploadURL, directory string, f api.UploadFile, size int64, options api.UploadOptions) error {
+	seeker, _ := f.Content.(io.Seeker)
+	var start int64
+	if seeker != nil {
+		var err error
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seeker = nil
+		}
+	}
+
+	for retried := false; ; retried = true {
+		if err := s.refreshUploadURL(ctx, u); err != nil {
+			return err
+		}
+		err := s.post(ctx, u.url, directory, f, size, options)
+		if err == nil || retried || seeker == nil || !pterrors.IsUnauthorized(err) {
+			return err
+		}
+		// Wings rejected the token, most likely because it expired.
+		u.url = ""
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind file: %w", err)
+		}
+	}
+}
+
+// post streams a single file to Wings through a pipe, so its content is
+// never held in memory.
+func (s *filesService) post(ctx context.Context, signedURL, directory string, f api.UploadFile, size int64, options api.UploadOptions) error {
+	endpoint, err := url.Parse(signedURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse upload URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("directory", directory)
+	endpoint.RawQuery = query.Encode()
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	written := make(chan error, 1)
+	go func() {
+		err := writeUpload(mw, f, size, options)
+		pw.CloseWithError(err)
+		written <- err
+	}()
+
+	err = s.sendUpload(ctx, endpoint.String(), pr, mw.FormDataContentType())
+	// Unblock the writer if Wings answered without reading the whole body.
+	pr.Close()
+	if werr := <-written; werr != nil && !errors.Is(werr, io.ErrClosedPipe) {
+		return werr
+	}
+	return err
+}
+
+func (s *filesService) sendUpload(ctx context.Context, endpoint string, body io.Reader, contentType string) error {
+	req, err := s.client.NewRequest(ctx, "POST", endpoint, body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	// Streamed so that the client timeout only covers the wait for Wings to
+	// answer, not the upload itself.
+	res, err := s.client.Stream(ctx, req)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func writeUpload(mw *multipart.Writer, f api.UploadFile, size int64, options api.UploadOptions) error {
+	part, err := mw.CreateFormFile("files", f.Name)
+	if err != nil {
+		return err
+	}
+	var w io.Writer = part
+	if options.Progress != nil {
+		w = &progressWriter{w: part, name: f.Name, total: size, progress: options.Progress}
+	}
+
+	if options.MaxSize > 0 {
+		n, err := io.CopyN(w, f.Content, options.MaxSize+1)
+		if n > options.MaxSize {
+			return &api.UploadTooLargeError{Name: f.Name, Size: n, Limit: options.MaxSize}
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+	} else if _, err := io.Copy(w, f.Content); err != nil {
+		return err
+	}
+	return mw.Close()
+}
+
+// progressWriter reports the bytes written through it.
+type progressWriter struct {
+	w        io.Writer
+	name     string
+	written  int64
+	total    int64
+	progress func(name string, uploaded, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if n > 0 {
+		p.progress(p.name, p.written, p.total)
+	}
+	return n, err
+}
+
+// uploadSize returns the size of a file, or -1 if it cannot be known without
+// reading it.
+func uploadSize(f api.UploadFile) int64 {
+	if f.Size > 0 {
+		return f.Size
+	}
+	switch c := f.Content.(type) {
+	case interface{ Len() int }:
+		return int64(c.Len())
+	case io.Seeker:
+		cur, err := c.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := c.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := c.Seek(cur, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - cur
+	}
+	return -1
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/crud"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
 )
 
@@ -40,6 +41,20 @@ func (s *networkService) ListAllocations(ctx context.Context, options api.Pagina
 	return results, &res.Meta, nil
 }
 
+// ListAllAllocations fetches every page of allocations.
+func (s *networkService) ListAllAllocations(ctx context.Context) ([]*api.Allocation, error) {
+	return crud.ListAllFunc[api.Allocation](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Allocation, *api.Meta, error) {
+		return s.ListAllocations(ctx, *o)
+	})
+}
+
+// IterAllocations returns an iterator that fetches allocations one page at a time.
+func (s *networkService) IterAllocations(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Allocation] {
+	return crud.IterateFunc[api.Allocation](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Allocation, *api.Meta, error) {
+		return s.ListAllocations(ctx, *o)
+	})
+}
+
 // AssignAllocation requests that a new allocation be automatically assigned to the server.
 func (s *networkService) AssignAllocation(ctx context.Context) (*api.Allocation, error) {
 	endpoint := fmt.Sprintf("/api/client/servers/%s/network/allocations", s.serverIdentifier)
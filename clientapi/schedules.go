@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/crud"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
 )
 
@@ -40,6 +41,20 @@ func (s *schedulesService) List(ctx context.Context, options api.PaginationOptio
 	return results, &res.Meta, nil
 }
 
+// ListAll fetches every page of schedules.
+func (s *schedulesService) ListAll(ctx context.Context) ([]*api.Schedule, error) {
+	return crud.ListAllFunc[api.Schedule](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Schedule, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
+// Iter returns an iterator that fetches schedules one page at a time.
+func (s *schedulesService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Schedule] {
+	return crud.IterateFunc[api.Schedule](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Schedule, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
 // Create sends a request to create a new schedule.
 func (s *schedulesService) Create(ctx context.Context, options api.ScheduleCreateOptions) (*api.Schedule, error) {
 	jsonBytes, err := json.Marshal(options)
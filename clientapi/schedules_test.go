@@ -258,3 +258,31 @@ func TestSchedulesService_DeleteTask(t *testing.T) {
 		}
 	})
 }
+
+func TestSchedulesService_ListAll(t *testing.T) {
+	page := func(n int, ids ...int) testutil.MockResponse {
+		data := make([]*api.ListItem[api.Schedule], len(ids))
+		for i, id := range ids {
+			data[i] = &api.ListItem[api.Schedule]{Object: "schedule", Attributes: &api.Schedule{ID: id}}
+		}
+		body, _ := json.Marshal(api.PaginatedResponse[api.Schedule]{
+			Object: "list",
+			Data:   data,
+			Meta:   api.Meta{Pagination: api.Pagination{Total: 3, PerPage: 2, CurrentPage: n, TotalPages: 2}},
+		})
+		return testutil.MockResponse{StatusCode: http.StatusOK, Body: body}
+	}
+	mock := &testutil.MockRequester{Responses: []testutil.MockResponse{page(1, 1, 2), page(2, 3)}}
+	s := newSchedulesService(mock, testServerIdentifier)
+
+	schedules, err := s.ListAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 3 || schedules[2].ID != 3 {
+		t.Errorf("expected 3 schedules, got %+v", schedules)
+	}
+	if len(mock.Requests) != 2 || mock.Requests[1].Options.Page != 2 {
+		t.Errorf("expected the second page to be requested, got %+v", mock.Requests)
+	}
+}
@@ -87,7 +87,7 @@ func (s *serverService) SendCommand(ctx context.Context, command string) error {
 }
 
 // SetPowerState changes the power state of the server.
-func (s *serverService) SetPowerState(ctx context.Context, signal string) error {
+func (s *serverService) SetPowerState(ctx context.Context, signal api.PowerSignal) error {
 	opts := api.SetPowerStateOptions{Signal: signal}
 	jsonBytes, err := json.Marshal(opts)
 	if err != nil {
@@ -116,7 +116,7 @@ func TestServerService_SendCommand(t *testing.T) {
 }
 
 func TestServerService_SetPowerState(t *testing.T) {
-	signal := "start"
+	signal := api.PowerStart
 	options := api.SetPowerStateOptions{Signal: signal}
 	jsonBody, _ := json.Marshal(options)
 
@@ -140,7 +140,7 @@ func TestServerService_SetPowerState(t *testing.T) {
 			Responses: []testutil.MockResponse{{Err: &errors.APIError{HTTPStatusCode: http.StatusConflict}}},
 		}
 		s := newServerService(mock, testServerIdentifier)
-		err := s.SetPowerState(context.Background(), "stop")
+		err := s.SetPowerState(context.Background(), api.PowerStop)
 		if err == nil {
 			t.Fatal("expected an error")
 		}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/crud"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
 )
 
@@ -43,6 +44,20 @@ func (s *startupService) ListVariables(ctx context.Context, options api.Paginati
 	return results, &res.Meta, nil
 }
 
+// ListAllVariables fetches every page of startup variables.
+func (s *startupService) ListAllVariables(ctx context.Context) ([]*api.StartupVariable, error) {
+	return crud.ListAllFunc[api.StartupVariable](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.StartupVariable, *api.Meta, error) {
+		return s.ListVariables(ctx, *o)
+	})
+}
+
+// IterVariables returns an iterator that fetches startup variables one page at a time.
+func (s *startupService) IterVariables(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.StartupVariable] {
+	return crud.IterateFunc[api.StartupVariable](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.StartupVariable, *api.Meta, error) {
+		return s.ListVariables(ctx, *o)
+	})
+}
+
 // UpdateVariable updates the value of a single startup variable.
 func (s *startupService) UpdateVariable(ctx context.Context, options api.UpdateVariableOptions) (*api.StartupVariable, error) {
 	jsonBytes, err := json.Marshal(options)
@@ -21,7 +21,7 @@ func TestStartupService_ListVariables(t *testing.T) {
 			Description:  "The JAR file to use for the server.",
 			EnvVariable:  "SERVER_JARFILE",
 			DefaultValue: "server.jar",
-			ServerValue:  "paper.jar",
+			ServerValue:  ptr("paper.jar"),
 			IsEditable:   true,
 			Rules:        "required|string",
 		},
@@ -30,7 +30,7 @@ func TestStartupService_ListVariables(t *testing.T) {
 			Description:  "The amount of memory to allocate.",
 			EnvVariable:  "SERVER_MEMORY",
 			DefaultValue: "1024",
-			ServerValue:  "4096",
+			ServerValue:  ptr("4096"),
 			IsEditable:   true,
 			Rules:        "required|numeric",
 		},
@@ -87,7 +87,7 @@ func TestStartupService_UpdateVariable(t *testing.T) {
 	expectedVar := &api.StartupVariable{
 		Name:        "Server Memory",
 		EnvVariable: "SERVER_MEMORY",
-		ServerValue: options.Value,
+		ServerValue: &options.Value,
 	}
 	res := api.UpdateVariableResponse{Object: "startup_variable", Attributes: expectedVar}
 	jsonBody, _ := json.Marshal(res)
@@ -128,3 +128,5 @@ func TestStartupService_UpdateVariable(t *testing.T) {
 		}
 	})
 }
+
+func ptr[T any](v T) *T { return &v }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/crud"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
 )
 
@@ -38,6 +39,20 @@ func (s *usersService) List(ctx context.Context, options api.PaginationOptions)
 	return results, &res.Meta, nil
 }
 
+// ListAll fetches every page of subusers.
+func (s *usersService) ListAll(ctx context.Context) ([]*api.Subuser, error) {
+	return crud.ListAllFunc[api.Subuser](ctx, s.client, 100, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Subuser, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
+// Iter returns an iterator that fetches subusers one page at a time.
+func (s *usersService) Iter(ctx context.Context, options api.PaginationOptions) *api.Iterator[api.Subuser] {
+	return crud.IterateFunc[api.Subuser](ctx, &options, func(ctx context.Context, o *api.PaginationOptions) ([]*api.Subuser, *api.Meta, error) {
+		return s.List(ctx, *o)
+	})
+}
+
 // Create sends a request to add a new subuser to the server.
 func (s *usersService) Create(ctx context.Context, options api.SubuserCreateOptions) (*api.Subuser, error) {
 	jsonBytes, err := json.Marshal(options)
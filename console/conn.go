@@ -0,0 +1,322 @@
+// Package console implements a client for the Wings websocket that backs a
+// server's console in the panel.
+//
+// The panel only hands out the credentials (see
+// clientapi.ServersService.GetWebsocket); this package dials the socket,
+// authenticates and exposes the event stream:
+//
+//	conn, err := console.Connect(ctx, sdk.ClientAPI.Servers("1a2b3c4d"),
+//		console.WithOrigin("https://panel.example.com"))
+//	if err != nil { … }
+//	defer conn.Close()
+//
+//	sub := conn.Subscribe(console.EventConsoleOutput)
+//	for ev := range sub.C {
+//		fmt.Println(ev.Arg())
+//	}
+//
+// Wings tokens expire after a few minutes; long-lived consumers should use
+// OpenSession, which refreshes the token and reconnects transparently.
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when sending on a connection that has terminated.
+var ErrClosed = errors.New("console: connection closed")
+
+// DetailsProvider is satisfied by clientapi.ServersService.
+type DetailsProvider interface {
+	GetWebsocket(ctx context.Context) (*api.WebsocketDetails, error)
+}
+
+// Console is the surface shared by Conn and the helpers built on top of it.
+type Console interface {
+	Subscribe(events ...string) *Subscription
+	Send(ev Event) error
+}
+
+// Option configures a Conn.
+type Option func(*config)
+
+type config struct {
+	dialer       *websocket.Dialer
+	header       http.Header
+	handlers     Handlers
+	buffer       int
+	writeTimeout time.Duration
+
+	// Session-only settings.
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+
+	pollInterval time.Duration
+	pollErr      func(error)
+
+	// Set internally by Session so that subscriptions outlive a single Conn.
+	hub     *hub
+	onEvent func(Event)
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		dialer:       websocket.DefaultDialer,
+		header:       http.Header{},
+		buffer:       64,
+		writeTimeout: 10 * time.Second,
+		minBackoff:   time.Second,
+		maxBackoff:   30 * time.Second,
+		pollInterval: 10 * time.Second,
+	}
+	for _, o := range opts {
+		o(cfg)
+	}
+	return cfg
+}
+
+// WithOrigin sets the Origin header sent during the handshake. Wings rejects
+// connections whose origin does not match the panel URL, so this is required
+// in practice.
+func WithOrigin(origin string) Option {
+	return func(c *config) { c.header.Set("Origin", origin) }
+}
+
+// WithHeader adds an extra header to the handshake request.
+func WithHeader(key, value string) Option {
+	return func(c *config) { c.header.Add(key, value) }
+}
+
+// WithDialer replaces websocket.DefaultDialer, e.g. to set a proxy or TLS config.
+func WithDialer(d *websocket.Dialer) Option {
+	return func(c *config) { c.dialer = d }
+}
+
+// WithHandlers registers callbacks for the typed events.
+func WithHandlers(h Handlers) Option {
+	return func(c *config) { c.handlers = h }
+}
+
+// WithBufferSize sets the channel capacity of each subscription (default 64).
+func WithBufferSize(n int) Option {
+	return func(c *config) { c.buffer = n }
+}
+
+// Conn is a single authenticated websocket connection to Wings.
+// Conn is safe for concurrent use.
+type Conn struct {
+	ws  *websocket.Conn
+	cfg *config
+	hub *hub
+
+	writeMu sync.Mutex
+
+	done      chan struct{}
+	stopped   chan struct{} // closed once the read loop has returned
+	closeOnce sync.Once
+	err       error
+}
+
+// Connect fetches fresh websocket credentials from p and dials Wings.
+func Connect(ctx context.Context, p DetailsProvider, opts ...Option) (*Conn, error) {
+	details, err := p.GetWebsocket(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("console: failed to fetch websocket details: %w", err)
+	}
+	return Dial(ctx, details, opts...)
+}
+
+// Dial opens the socket described by details and authenticates with its
+// token. It returns once Wings has acknowledged the token; if it fails, the
+// read loop has stopped by the time it returns.
+func Dial(ctx context.Context, details *api.WebsocketDetails, opts ...Option) (*Conn, error) {
+	cfg := newConfig(opts)
+
+	ws, res, err := cfg.dialer.DialContext(ctx, details.SocketURL, cfg.header)
+	if err != nil {
+		if res != nil {
+			return nil, fmt.Errorf("console: failed to dial websocket (status %d): %w", res.StatusCode, err)
+		}
+		return nil, fmt.Errorf("console: failed to dial websocket: %w", err)
+	}
+
+	h := cfg.hub
+	if h == nil {
+		h = newHub(cfg.buffer)
+	}
+	c := &Conn{ws: ws, cfg: cfg, hub: h, done: make(chan struct{}), stopped: make(chan struct{})}
+
+	// Subscribe before the read loop starts so the acknowledgement cannot be missed.
+	ack := newHub(1)
+	ackSub := ack.subscribe(EventAuthSuccess, EventJWTError)
+	defer ackSub.Close()
+	go c.readLoop(ack)
+
+	// A Session dials again as soon as this returns, so the read loop must
+	// be gone before then: the session hub allows one publisher at a time.
+	fail := func(err error) (*Conn, error) {
+		c.Close()
+		<-c.stopped
+		return nil, err
+	}
+	if err = c.Authenticate(details.Token); err != nil {
+		return fail(err)
+	}
+
+	select {
+	case ev, ok := <-ackSub.C:
+		if !ok {
+			return fail(fmt.Errorf("console: connection closed during authentication: %w", c.Err()))
+		}
+		if ev.Name == EventJWTError {
+			return fail(fmt.Errorf("console: authentication rejected: %s", ev.Arg()))
+		}
+	case <-ctx.Done():
+		return fail(ctx.Err())
+	}
+	return c, nil
+}
+
+func (c *Conn) readLoop(ack *hub) {
+	defer func() {
+		c.shutdown(nil)
+		ack.close()
+		if c.cfg.hub == nil {
+			c.hub.close()
+		}
+		close(c.stopped)
+	}()
+
+	// Events only reach subscribers once Wings has accepted the token, so a
+	// rejected connection never shows up on a Session's subscriptions.
+	authenticated := false
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			c.shutdown(err)
+			return
+		}
+
+		var ev Event
+		if err = json.Unmarshal(data, &ev); err != nil {
+			continue
+		}
+		ack.publish(ev, c.done)
+		if ev.Name == EventAuthSuccess {
+			authenticated = true
+		}
+		if !authenticated {
+			continue
+		}
+		if c.cfg.onEvent != nil {
+			c.cfg.onEvent(ev)
+		}
+		c.cfg.handlers.dispatch(ev)
+		c.hub.publish(ev, c.done)
+	}
+}
+
+// shutdown records the first terminal error and releases the socket.
+func (c *Conn) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		if err == nil {
+			err = ErrClosed
+		}
+		c.err = err
+		c.ws.Close()
+		close(c.done)
+	})
+}
+
+// Subscribe returns a subscription receiving the named events, or every
+// event when no names are given. Subscribers must keep draining C: the read
+// loop waits for each subscriber before moving to the next frame.
+func (c *Conn) Subscribe(events ...string) *Subscription {
+	return c.hub.subscribe(events...)
+}
+
+// Send writes a single event to Wings.
+func (c *Conn) Send(ev Event) error {
+	select {
+	case <-c.done:
+		return ErrClosed
+	default:
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("console: failed to marshal event: %w", err)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err = c.ws.SetWriteDeadline(time.Now().Add(c.cfg.writeTimeout)); err != nil {
+		return err
+	}
+	if err = c.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+		return fmt.Errorf("console: failed to send %q: %w", ev.Name, err)
+	}
+	return nil
+}
+
+// Authenticate sends an "auth" event. Wings accepts it at any time, which is
+// how an expiring token is replaced without reconnecting.
+func (c *Conn) Authenticate(token string) error {
+	return c.Send(Event{Name: EventAuth, Args: []string{token}})
+}
+
+// SendCommand writes a line to the server's console.
+func (c *Conn) SendCommand(command string) error {
+	return c.Send(Event{Name: EventSendCommand, Args: []string{command}})
+}
+
+// SetState sends a power action over the socket.
+func (c *Conn) SetState(signal api.PowerSignal) error {
+	return c.Send(Event{Name: EventSetState, Args: []string{string(signal)}})
+}
+
+// RequestLogs asks Wings to replay the recent console history.
+func (c *Conn) RequestLogs() error {
+	return c.Send(Event{Name: EventSendLogs})
+}
+
+// RequestStats asks Wings to emit a "stats" event immediately.
+func (c *Conn) RequestStats() error {
+	return c.Send(Event{Name: EventSendStats})
+}
+
+// Done is closed when the connection terminates.
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err reports why the connection terminated. It returns nil while the
+// connection is still open.
+func (c *Conn) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Close sends a close frame and tears the connection down.
+func (c *Conn) Close() error {
+	c.writeMu.Lock()
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = c.ws.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	c.writeMu.Unlock()
+
+	c.shutdown(nil)
+	return nil
+}
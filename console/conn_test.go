@@ -0,0 +1,224 @@
+package console
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+)
+
+type fakeDetails struct {
+	wings *testutil.WingsServer
+	token string
+	calls int
+}
+
+func (f *fakeDetails) GetWebsocket(ctx context.Context) (*api.WebsocketDetails, error) {
+	f.calls++
+	return f.wings.Details(f.token), nil
+}
+
+func receive(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev, ok := <-ch:
+		if !ok {
+			t.Fatal("subscription closed unexpectedly")
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func receiveFrame(t *testing.T, ch <-chan testutil.WingsEvent, name string) testutil.WingsEvent {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Event == name {
+				return ev
+			}
+		case <-deadline:
+			t.Fatalf("timed out waiting for %q frame", name)
+		}
+	}
+}
+
+func TestConnect(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	t.Run("success", func(t *testing.T) {
+		p := &fakeDetails{wings: wings, token: "good-token"}
+		conn, err := Connect(context.Background(), p)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer conn.Close()
+
+		auth := receiveFrame(t, wings.Received, EventAuth)
+		if len(auth.Args) != 1 || auth.Args[0] != "good-token" {
+			t.Errorf("expected auth with token, got %+v", auth)
+		}
+		if p.calls != 1 {
+			t.Errorf("expected 1 GetWebsocket call, got %d", p.calls)
+		}
+	})
+
+	t.Run("rejected token", func(t *testing.T) {
+		wings.AcceptToken = func(token string) bool { return token == "good-token" }
+		defer func() { wings.AcceptToken = nil }()
+
+		_, err := Connect(context.Background(), &fakeDetails{wings: wings, token: "bad-token"})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestConn_Subscribe(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	conn, err := Dial(context.Background(), wings.Details("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	output := conn.Subscribe(EventConsoleOutput)
+	all := conn.Subscribe()
+
+	wings.Emit(EventStatus, "running")
+	wings.Emit(EventConsoleOutput, "Done (3.2s)! For help, type \"help\"")
+
+	if ev := receive(t, output.C); ev.Arg() != "Done (3.2s)! For help, type \"help\"" {
+		t.Errorf("unexpected console line %q", ev.Arg())
+	}
+	if ev := receive(t, all.C); ev.Name != EventStatus || ev.Arg() != "running" {
+		t.Errorf("expected status event, got %+v", ev)
+	}
+	if ev := receive(t, all.C); ev.Name != EventConsoleOutput {
+		t.Errorf("expected console output event, got %+v", ev)
+	}
+
+	t.Run("closed on disconnect", func(t *testing.T) {
+		wings.DropConnections()
+		select {
+		case _, ok := <-output.C:
+			if ok {
+				t.Fatal("expected channel to be closed")
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for close")
+		}
+		if conn.Err() == nil {
+			t.Error("expected Err to be set after disconnect")
+		}
+		if err := conn.SendCommand("list"); err != ErrClosed {
+			t.Errorf("expected ErrClosed, got %v", err)
+		}
+	})
+}
+
+func TestConn_Handlers(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	stats := make(chan *Stats, 1)
+	backups := make(chan *BackupCompleted, 1)
+	expiring := make(chan struct{}, 1)
+	errs := make(chan string, 1)
+
+	conn, err := Dial(context.Background(), wings.Details("token"), WithHandlers(Handlers{
+		Stats:           func(s *Stats) { stats <- s },
+		BackupCompleted: func(b *BackupCompleted) { backups <- b },
+		TokenExpiring:   func() { expiring <- struct{}{} },
+		DaemonError:     func(msg string) { errs <- msg },
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	wings.Emit(EventStats, `{"memory_bytes":1024,"memory_limit_bytes":4096,"cpu_absolute":12.5,"network":{"rx_bytes":10,"tx_bytes":20},"uptime":5000,"state":"running","disk_bytes":2048}`)
+	wings.Emit(EventBackupCompleted, `{"uuid":"backup-uuid","is_successful":true,"checksum":"abc","checksum_type":"sha1","file_size":99}`)
+	wings.Emit(EventTokenExpiring)
+	wings.Emit(EventDaemonError, "boom")
+
+	select {
+	case s := <-stats:
+		if s.MemoryBytes != 1024 || s.CPUAbsolute != 12.5 || s.Network.TxBytes != 20 || s.State != "running" {
+			t.Errorf("unexpected stats %+v", s)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stats handler not called")
+	}
+	select {
+	case b := <-backups:
+		if b.UUID != "backup-uuid" || !b.IsSuccessful || b.FileSize != 99 {
+			t.Errorf("unexpected backup %+v", b)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("backup handler not called")
+	}
+	select {
+	case <-expiring:
+	case <-time.After(2 * time.Second):
+		t.Fatal("token expiring handler not called")
+	}
+	select {
+	case msg := <-errs:
+		if msg != "boom" {
+			t.Errorf("expected daemon error %q, got %q", "boom", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("daemon error handler not called")
+	}
+}
+
+func TestConn_Send(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	conn, err := Dial(context.Background(), wings.Details("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	if err = conn.SendCommand("say hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev := receiveFrame(t, wings.Received, EventSendCommand); ev.Args[0] != "say hello" {
+		t.Errorf("expected command %q, got %+v", "say hello", ev)
+	}
+
+	if err = conn.SetState(api.PowerRestart); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev := receiveFrame(t, wings.Received, EventSetState); ev.Args[0] != "restart" {
+		t.Errorf("expected state %q, got %+v", "restart", ev)
+	}
+
+	if err = conn.RequestLogs(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	receiveFrame(t, wings.Received, EventSendLogs)
+}
+
+func TestEvent_Stats(t *testing.T) {
+	_, err := Event{Name: EventStatus, Args: []string{"running"}}.Stats()
+	if err == nil {
+		t.Error("expected an error decoding stats from a status event")
+	}
+	_, err = Event{Name: EventStats, Args: []string{"not json"}}.Stats()
+	if err == nil {
+		t.Error("expected an error decoding malformed stats")
+	}
+}
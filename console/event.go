@@ -0,0 +1,154 @@
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event names used by the Wings websocket protocol.
+// Events flowing from Wings to the client:
+const (
+	EventAuthSuccess     = "auth success"
+	EventConsoleOutput   = "console output"
+	EventStatus          = "status"
+	EventStats           = "stats"
+	EventInstallOutput   = "install output"
+	EventInstallStarted  = "install started"
+	EventInstallDone     = "install completed"
+	EventDaemonMessage   = "daemon message"
+	EventDaemonError     = "daemon error"
+	EventBackupCompleted = "backup completed"
+	EventBackupRestored  = "backup restore completed"
+	EventTransferLogs    = "transfer logs"
+	EventTransferStatus  = "transfer status"
+	EventTokenExpiring   = "token expiring"
+	EventTokenExpired    = "token expired"
+	EventJWTError        = "jwt error"
+)
+
+// Events flowing from the client to Wings:
+const (
+	EventAuth        = "auth"
+	EventSendLogs    = "send logs"
+	EventSendStats   = "send stats"
+	EventSendCommand = "send command"
+	EventSetState    = "set state"
+)
+
+// Event is a single frame of the Wings websocket protocol.
+type Event struct {
+	Name string   `json:"event"`
+	Args []string `json:"args,omitempty"`
+}
+
+// Arg returns the first argument of the event, which for almost every event
+// carries the payload (a console line, a state name, a JSON document…).
+func (e Event) Arg() string {
+	if len(e.Args) == 0 {
+		return ""
+	}
+	return e.Args[0]
+}
+
+// Stats is the payload of a "stats" event.
+type Stats struct {
+	MemoryBytes      int64   `json:"memory_bytes"`
+	MemoryLimitBytes int64   `json:"memory_limit_bytes"`
+	CPUAbsolute      float64 `json:"cpu_absolute"`
+	DiskBytes        int64   `json:"disk_bytes"`
+	Network          struct {
+		RxBytes int64 `json:"rx_bytes"`
+		TxBytes int64 `json:"tx_bytes"`
+	} `json:"network"`
+	// Uptime is reported by Wings in milliseconds.
+	Uptime int64  `json:"uptime"`
+	State  string `json:"state"`
+}
+
+// BackupCompleted is the payload of a "backup completed" event.
+type BackupCompleted struct {
+	UUID         string `json:"uuid"`
+	IsSuccessful bool   `json:"is_successful"`
+	Checksum     string `json:"checksum"`
+	ChecksumType string `json:"checksum_type"`
+	FileSize     int64  `json:"file_size"`
+}
+
+// Stats decodes the JSON document carried by a "stats" event.
+func (e Event) Stats() (*Stats, error) {
+	if e.Name != EventStats {
+		return nil, fmt.Errorf("console: cannot decode stats from %q event", e.Name)
+	}
+	stats := &Stats{}
+	if err := json.Unmarshal([]byte(e.Arg()), stats); err != nil {
+		return nil, fmt.Errorf("console: failed to decode stats: %w", err)
+	}
+	return stats, nil
+}
+
+// BackupCompleted decodes the JSON document carried by a "backup completed" event.
+func (e Event) BackupCompleted() (*BackupCompleted, error) {
+	if e.Name != EventBackupCompleted {
+		return nil, fmt.Errorf("console: cannot decode backup from %q event", e.Name)
+	}
+	backup := &BackupCompleted{}
+	if err := json.Unmarshal([]byte(e.Arg()), backup); err != nil {
+		return nil, fmt.Errorf("console: failed to decode backup: %w", err)
+	}
+	return backup, nil
+}
+
+// Handlers are optional callbacks invoked for the typed events. They run on
+// the connection's read goroutine and must therefore not block.
+type Handlers struct {
+	ConsoleOutput   func(line string)
+	Status          func(state string)
+	Stats           func(stats *Stats)
+	InstallOutput   func(line string)
+	DaemonError     func(message string)
+	BackupCompleted func(backup *BackupCompleted)
+	TokenExpiring   func()
+	TokenExpired    func()
+}
+
+// dispatch routes ev to the matching callback, if one is set.
+func (h *Handlers) dispatch(ev Event) {
+	switch ev.Name {
+	case EventConsoleOutput:
+		if h.ConsoleOutput != nil {
+			h.ConsoleOutput(ev.Arg())
+		}
+	case EventStatus:
+		if h.Status != nil {
+			h.Status(ev.Arg())
+		}
+	case EventStats:
+		if h.Stats != nil {
+			if stats, err := ev.Stats(); err == nil {
+				h.Stats(stats)
+			}
+		}
+	case EventInstallOutput:
+		if h.InstallOutput != nil {
+			h.InstallOutput(ev.Arg())
+		}
+	case EventDaemonError:
+		if h.DaemonError != nil {
+			h.DaemonError(ev.Arg())
+		}
+	case EventBackupCompleted:
+		if h.BackupCompleted != nil {
+			if backup, err := ev.BackupCompleted(); err == nil {
+				h.BackupCompleted(backup)
+			}
+		}
+	case EventTokenExpiring:
+		if h.TokenExpiring != nil {
+			h.TokenExpiring()
+		}
+	case EventTokenExpired:
+		if h.TokenExpired != nil {
+			h.TokenExpired()
+		}
+	}
+}
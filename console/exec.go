@@ -0,0 +1,89 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// ErrNoMatch is returned by Exec when output went idle before a line
+// matching CaptureOptions.Until was seen.
+var ErrNoMatch = errors.New("console: output went idle before a matching line")
+
+// DefaultIdleTimeout is used by Exec when CaptureOptions sets no stop condition.
+const DefaultIdleTimeout = 2 * time.Second
+
+// CaptureOptions decides when Exec stops collecting console output. The
+// first condition met wins; when none is set, DefaultIdleTimeout applies.
+type CaptureOptions struct {
+	// Until stops collection after the first line it matches. That line is
+	// included in the result.
+	Until *regexp.Regexp
+	// Lines stops collection once this many lines have been captured.
+	Lines int
+	// IdleTimeout stops collection when no line arrives for this long.
+	IdleTimeout time.Duration
+}
+
+// Exec sends command to the server console and returns the console lines
+// written in response, giving RCON-like semantics on top of the websocket:
+//
+//	lines, err := console.Exec(ctx, session, "list", console.CaptureOptions{
+//		Until: regexp.MustCompile(`players online`),
+//	})
+//
+// Output is not correlated with the command: any line the server prints
+// while Exec is listening is captured.
+func Exec(ctx context.Context, c Console, command string, opts CaptureOptions) ([]string, error) {
+	idle := opts.IdleTimeout
+	if idle <= 0 && opts.Until == nil && opts.Lines <= 0 {
+		idle = DefaultIdleTimeout
+	}
+
+	sub := c.Subscribe(EventConsoleOutput)
+	defer sub.Close()
+
+	if err := c.Send(Event{Name: EventSendCommand, Args: []string{command}}); err != nil {
+		return nil, err
+	}
+
+	var timer *time.Timer
+	var idleC <-chan time.Time
+	if idle > 0 {
+		timer = time.NewTimer(idle)
+		defer timer.Stop()
+		idleC = timer.C
+	}
+
+	var lines []string
+	for {
+		select {
+		case <-ctx.Done():
+			return lines, ctx.Err()
+		case <-idleC:
+			if opts.Until != nil {
+				return lines, ErrNoMatch
+			}
+			return lines, nil
+		case ev, ok := <-sub.C:
+			if !ok {
+				return lines, ErrClosed
+			}
+			line := ev.Arg()
+			lines = append(lines, line)
+			if opts.Until != nil && opts.Until.MatchString(line) {
+				return lines, nil
+			}
+			if opts.Lines > 0 && len(lines) >= opts.Lines {
+				return lines, nil
+			}
+			if timer != nil {
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(idle)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package console
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+)
+
+func newListServer() *testutil.WingsServer {
+	wings := testutil.NewWingsServer()
+	wings.Respond = func(ev testutil.WingsEvent) []testutil.WingsEvent {
+		if ev.Event != EventSendCommand || ev.Args[0] != "list" {
+			return nil
+		}
+		return []testutil.WingsEvent{
+			{Event: EventConsoleOutput, Args: []string{"There are 2 of a max of 20 players online: alex, steve"}},
+			{Event: EventConsoleOutput, Args: []string{"[Server thread/INFO]: unrelated"}},
+		}
+	}
+	return wings
+}
+
+func TestExec(t *testing.T) {
+	wings := newListServer()
+	defer wings.Close()
+
+	conn, err := Dial(context.Background(), wings.Details("token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	testCases := []struct {
+		name     string
+		opts     CaptureOptions
+		expected []string
+		err      error
+	}{
+		{
+			name:     "until match",
+			opts:     CaptureOptions{Until: regexp.MustCompile(`players online`)},
+			expected: []string{"There are 2 of a max of 20 players online: alex, steve"},
+		},
+		{
+			name: "line count",
+			opts: CaptureOptions{Lines: 2},
+			expected: []string{
+				"There are 2 of a max of 20 players online: alex, steve",
+				"[Server thread/INFO]: unrelated",
+			},
+		},
+		{
+			name: "idle timeout",
+			opts: CaptureOptions{IdleTimeout: 100 * time.Millisecond},
+			expected: []string{
+				"There are 2 of a max of 20 players online: alex, steve",
+				"[Server thread/INFO]: unrelated",
+			},
+		},
+		{
+			name: "idle before match",
+			opts: CaptureOptions{Until: regexp.MustCompile(`never printed`), IdleTimeout: 100 * time.Millisecond},
+			expected: []string{
+				"There are 2 of a max of 20 players online: alex, steve",
+				"[Server thread/INFO]: unrelated",
+			},
+			err: ErrNoMatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := Exec(context.Background(), conn, "list", tc.opts)
+			if err != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if !reflect.DeepEqual(lines, tc.expected) {
+				t.Errorf("expected lines %q, got %q", tc.expected, lines)
+			}
+		})
+	}
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := Exec(ctx, conn, "save-all", CaptureOptions{Until: regexp.MustCompile(`Saved the game`)})
+		if err != context.DeadlineExceeded {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
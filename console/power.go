@@ -0,0 +1,113 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"time"
+)
+
+// DefaultGracePeriod is how long StopAndWait waits after "stop" before it
+// escalates to "kill".
+const DefaultGracePeriod = 30 * time.Second
+
+// PowerController is satisfied by clientapi.ServersService.
+type PowerController interface {
+	StatsSource
+	SetPowerState(ctx context.Context, signal api.PowerSignal) error
+}
+
+// PowerOptions tunes the *AndWait helpers. The zero value is usable.
+type PowerOptions struct {
+	// GracePeriod is how long a stop may take before the server is killed.
+	// Zero means DefaultGracePeriod; a negative value never escalates.
+	GracePeriod time.Duration
+	// Options are passed to WatchStats, which observes the server's state.
+	Options []Option
+}
+
+// StartAndWait sends "start" and blocks until the server reports running.
+func StartAndWait(ctx context.Context, srv PowerController, opts PowerOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	states := WatchStats(ctx, srv, opts.Options...)
+
+	if err := srv.SetPowerState(ctx, api.PowerStart); err != nil {
+		return err
+	}
+	return waitForState(ctx, states, api.ServerStateRunning, nil, nil)
+}
+
+// StopAndWait sends "stop" and blocks until the server reports offline. If
+// that takes longer than the grace period, "kill" is sent and the wait
+// continues.
+func StopAndWait(ctx context.Context, srv PowerController, opts PowerOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	states := WatchStats(ctx, srv, opts.Options...)
+	return stopAndWait(ctx, srv, states, opts)
+}
+
+// RestartAndWait stops the server (escalating to kill like StopAndWait) and
+// starts it again, returning once it reports running.
+func RestartAndWait(ctx context.Context, srv PowerController, opts PowerOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	states := WatchStats(ctx, srv, opts.Options...)
+
+	if err := stopAndWait(ctx, srv, states, opts); err != nil {
+		return err
+	}
+	if err := srv.SetPowerState(ctx, api.PowerStart); err != nil {
+		return err
+	}
+	return waitForState(ctx, states, api.ServerStateRunning, nil, nil)
+}
+
+func stopAndWait(ctx context.Context, srv PowerController, states <-chan api.ResourceAttributes, opts PowerOptions) error {
+	if err := srv.SetPowerState(ctx, api.PowerStop); err != nil {
+		return err
+	}
+
+	grace := opts.GracePeriod
+	if grace == 0 {
+		grace = DefaultGracePeriod
+	}
+	var deadline <-chan time.Time
+	if grace > 0 {
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	kill := func() error { return srv.SetPowerState(ctx, api.PowerKill) }
+	return waitForState(ctx, states, api.ServerStateOffline, deadline, kill)
+}
+
+// waitForState consumes states until target is reported. When deadline
+// fires, escalate is called once and the wait goes on.
+func waitForState(ctx context.Context, states <-chan api.ResourceAttributes, target string,
+	deadline <-chan time.Time, escalate func() error) error {
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			deadline = nil
+			if err := escalate(); err != nil {
+				return err
+			}
+		case attrs, ok := <-states:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("console: state watcher stopped before server was %s", target)
+			}
+			if attrs.CurrentState == target {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package console
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+)
+
+// fakePowerServer reacts to power signals by emitting status events, the
+// way Wings does, and answers "send stats" with its current state.
+type fakePowerServer struct {
+	fakeStatsSource
+	mu      sync.Mutex
+	state   string
+	signals []api.PowerSignal
+	// ignoreStop simulates a server that hangs on shutdown.
+	ignoreStop bool
+}
+
+func (f *fakePowerServer) SetPowerState(ctx context.Context, signal api.PowerSignal) error {
+	f.mu.Lock()
+	f.signals = append(f.signals, signal)
+	f.mu.Unlock()
+
+	switch signal {
+	case api.PowerStart:
+		f.transition(api.ServerStateStarting)
+		f.transition(api.ServerStateRunning)
+	case api.PowerStop:
+		f.transition(api.ServerStateStopping)
+		if !f.ignoreStop {
+			f.transition(api.ServerStateOffline)
+		}
+	case api.PowerKill:
+		f.transition(api.ServerStateOffline)
+	}
+	return nil
+}
+
+func (f *fakePowerServer) transition(state string) {
+	f.mu.Lock()
+	f.state = state
+	f.mu.Unlock()
+	f.wings.Emit(EventStatus, state)
+}
+
+func (f *fakePowerServer) respond(ev testutil.WingsEvent) []testutil.WingsEvent {
+	if ev.Event != EventSendStats {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return []testutil.WingsEvent{{Event: EventStats, Args: []string{`{"state":"` + f.state + `"}`}}}
+}
+
+func (f *fakePowerServer) sent() []api.PowerSignal {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]api.PowerSignal{}, f.signals...)
+}
+
+func newPowerServer(wings *testutil.WingsServer, state string) *fakePowerServer {
+	f := &fakePowerServer{
+		fakeStatsSource: fakeStatsSource{rotatingDetails: rotatingDetails{wings: wings}},
+		state:           state,
+	}
+	wings.Respond = f.respond
+	return f
+}
+
+func TestStartAndWait(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+	srv := newPowerServer(wings, api.ServerStateOffline)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := StartAndWait(ctx, srv, PowerOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signals := srv.sent(); !reflect.DeepEqual(signals, []api.PowerSignal{api.PowerStart}) {
+		t.Errorf("unexpected signals %v", signals)
+	}
+}
+
+func TestStopAndWait(t *testing.T) {
+	t.Run("graceful", func(t *testing.T) {
+		wings := testutil.NewWingsServer()
+		defer wings.Close()
+		srv := newPowerServer(wings, api.ServerStateRunning)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := StopAndWait(ctx, srv, PowerOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if signals := srv.sent(); !reflect.DeepEqual(signals, []api.PowerSignal{api.PowerStop}) {
+			t.Errorf("unexpected signals %v", signals)
+		}
+	})
+
+	t.Run("escalates to kill", func(t *testing.T) {
+		wings := testutil.NewWingsServer()
+		defer wings.Close()
+		srv := newPowerServer(wings, api.ServerStateRunning)
+		srv.ignoreStop = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := StopAndWait(ctx, srv, PowerOptions{GracePeriod: 50 * time.Millisecond}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []api.PowerSignal{api.PowerStop, api.PowerKill}
+		if signals := srv.sent(); !reflect.DeepEqual(signals, expected) {
+			t.Errorf("expected signals %v, got %v", expected, signals)
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		wings := testutil.NewWingsServer()
+		defer wings.Close()
+		srv := newPowerServer(wings, api.ServerStateRunning)
+		srv.ignoreStop = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := StopAndWait(ctx, srv, PowerOptions{GracePeriod: -1})
+		if err != context.DeadlineExceeded {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
+
+func TestRestartAndWait(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+	srv := newPowerServer(wings, api.ServerStateRunning)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := RestartAndWait(ctx, srv, PowerOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []api.PowerSignal{api.PowerStop, api.PowerStart}
+	if signals := srv.sent(); !reflect.DeepEqual(signals, expected) {
+		t.Errorf("expected signals %v, got %v", expected, signals)
+	}
+}
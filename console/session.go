@@ -0,0 +1,257 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrNotConnected is returned when sending on a Session that is between
+// connections.
+var ErrNotConnected = errors.New("console: session is reconnecting")
+
+// WithReconnectBackoff sets the bounds of the exponential back-off a Session
+// applies between reconnect attempts (default 1s to 30s).
+func WithReconnectBackoff(min, max time.Duration) Option {
+	return func(c *config) {
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
+}
+
+// WithMaxReconnectAttempts limits how many consecutive failed reconnects a
+// Session tolerates before giving up. Zero, the default, retries forever.
+func WithMaxReconnectAttempts(n int) Option {
+	return func(c *config) { c.maxAttempts = n }
+}
+
+// Session is a supervised console stream that survives token expiry and
+// network failures:
+//
+//   - on "token expiring" or "token expired" it fetches new credentials from
+//     the panel and re-authenticates the open socket;
+//   - when the socket drops it reconnects with jittered exponential back-off
+//     and sends "send logs" so the console history is replayed.
+//
+// Subscriptions taken on a Session stay open across reconnects and are only
+// closed when the Session itself ends. Session is safe for concurrent use.
+type Session struct {
+	provider DetailsProvider
+	opts     []Option
+	cfg      *config
+	hub      *hub
+	refresh  chan struct{}
+
+	mu   sync.Mutex
+	conn *Conn
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// OpenSession connects to the server's console and keeps the connection
+// alive until ctx is cancelled, Close is called, or reconnecting fails
+// more often than WithMaxReconnectAttempts allows.
+func OpenSession(ctx context.Context, p DetailsProvider, opts ...Option) (*Session, error) {
+	s := &Session{
+		provider: p,
+		cfg:      newConfig(opts),
+		refresh:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	s.hub = newHub(s.cfg.buffer)
+	s.opts = append(append([]Option{}, opts...), func(c *config) {
+		c.hub = s.hub
+		c.onEvent = s.onEvent
+	})
+
+	conn, err := Connect(ctx, p, s.opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	go s.run(ctx, conn)
+	return s, nil
+}
+
+// onEvent runs on the read loop of the active Conn.
+func (s *Session) onEvent(ev Event) {
+	if ev.Name != EventTokenExpiring && ev.Name != EventTokenExpired {
+		return
+	}
+	select {
+	case s.refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Session) run(ctx context.Context, conn *Conn) {
+	defer close(s.done)
+	defer s.hub.close()
+
+	for {
+		s.serve(ctx, conn)
+		<-conn.stopped
+
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+
+		if ctx.Err() != nil {
+			s.err = ErrClosed
+			return
+		}
+
+		var err error
+		if conn, err = s.reconnect(ctx); err != nil {
+			s.err = err
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+
+		// Replay the console history that was missed while disconnected.
+		_ = conn.RequestLogs()
+	}
+}
+
+// serve keeps conn authenticated until it terminates or ctx is cancelled.
+func (s *Session) serve(ctx context.Context, conn *Conn) {
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return
+		case <-conn.Done():
+			return
+		case <-s.refresh:
+			details, err := s.provider.GetWebsocket(ctx)
+			if err == nil {
+				err = conn.Authenticate(details.Token)
+			}
+			if err != nil {
+				// Fall back to a full reconnect, which retries with back-off.
+				conn.Close()
+			}
+		}
+	}
+}
+
+func (s *Session) reconnect(ctx context.Context) (*Conn, error) {
+	for attempt := 0; ; attempt++ {
+		if s.cfg.maxAttempts > 0 && attempt >= s.cfg.maxAttempts {
+			return nil, fmt.Errorf("console: giving up after %d reconnect attempts", attempt)
+		}
+
+		select {
+		case <-time.After(s.backoff(attempt)):
+		case <-ctx.Done():
+			return nil, ErrClosed
+		}
+
+		conn, err := Connect(ctx, s.provider, s.opts...)
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ErrClosed
+		}
+	}
+}
+
+// backoff returns a jittered delay in [d/2, d) where d doubles per attempt.
+func (s *Session) backoff(attempt int) time.Duration {
+	d := s.cfg.minBackoff
+	for i := 0; i < attempt && d < s.cfg.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.cfg.maxBackoff {
+		d = s.cfg.maxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// connected reports whether the session has an open connection, as opposed
+// to being between connections.
+func (s *Session) connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn != nil
+}
+
+// Subscribe returns a subscription that receives the named events (or every
+// event) for the whole lifetime of the session.
+func (s *Session) Subscribe(events ...string) *Subscription {
+	return s.hub.subscribe(events...)
+}
+
+// Send writes an event on the active connection.
+func (s *Session) Send(ev Event) error {
+	select {
+	case <-s.done:
+		return ErrClosed
+	default:
+	}
+
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return conn.Send(ev)
+}
+
+// SendCommand writes a line to the server's console.
+func (s *Session) SendCommand(command string) error {
+	return s.Send(Event{Name: EventSendCommand, Args: []string{command}})
+}
+
+// SetState sends a power action over the socket.
+func (s *Session) SetState(signal api.PowerSignal) error {
+	return s.Send(Event{Name: EventSetState, Args: []string{string(signal)}})
+}
+
+// RequestLogs asks Wings to replay the recent console history.
+func (s *Session) RequestLogs() error {
+	return s.Send(Event{Name: EventSendLogs})
+}
+
+// RequestStats asks Wings to emit a "stats" event immediately.
+func (s *Session) RequestStats() error {
+	return s.Send(Event{Name: EventSendStats})
+}
+
+// Done is closed when the session has ended for good.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err reports why the session ended. It returns nil while it is running.
+func (s *Session) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close ends the session and closes every subscription.
+func (s *Session) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
@@ -0,0 +1,173 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+)
+
+// rotatingDetails hands out a new token on every call.
+type rotatingDetails struct {
+	wings *testutil.WingsServer
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (r *rotatingDetails) GetWebsocket(ctx context.Context) (*api.WebsocketDetails, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.calls++
+	return r.wings.Details(fmt.Sprintf("token-%d", r.calls)), nil
+}
+
+func (r *rotatingDetails) fail(err error) {
+	r.mu.Lock()
+	r.err = err
+	r.mu.Unlock()
+}
+
+func TestSession_TokenRefresh(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	p := &rotatingDetails{wings: wings}
+	s, err := OpenSession(context.Background(), p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	receiveFrame(t, wings.Received, EventAuth)
+	output := s.Subscribe(EventConsoleOutput)
+
+	wings.Emit(EventTokenExpiring)
+	if ev := receiveFrame(t, wings.Received, EventAuth); ev.Args[0] != "token-2" {
+		t.Errorf("expected re-auth with token-2, got %+v", ev)
+	}
+
+	wings.Emit(EventConsoleOutput, "still here")
+	if ev := receive(t, output.C); ev.Arg() != "still here" {
+		t.Errorf("unexpected line %q", ev.Arg())
+	}
+	if dials := wings.Dials(); dials != 1 {
+		t.Errorf("expected token refresh on the same socket, got %d dials", dials)
+	}
+}
+
+func TestSession_Reconnect(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	p := &rotatingDetails{wings: wings}
+	s, err := OpenSession(context.Background(), p, WithReconnectBackoff(time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	output := s.Subscribe(EventConsoleOutput)
+	receiveFrame(t, wings.Received, EventAuth)
+
+	wings.DropConnections()
+
+	if ev := receiveFrame(t, wings.Received, EventAuth); ev.Args[0] != "token-2" {
+		t.Errorf("expected reconnect with fresh token, got %+v", ev)
+	}
+	receiveFrame(t, wings.Received, EventSendLogs)
+
+	wings.Emit(EventConsoleOutput, "after reconnect")
+	if ev := receive(t, output.C); ev.Arg() != "after reconnect" {
+		t.Errorf("unexpected line %q", ev.Arg())
+	}
+}
+
+func TestSession_GivesUp(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	p := &rotatingDetails{wings: wings}
+	s, err := OpenSession(context.Background(), p,
+		WithReconnectBackoff(time.Millisecond, time.Millisecond),
+		WithMaxReconnectAttempts(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub := s.Subscribe()
+
+	p.fail(fmt.Errorf("panel unavailable"))
+	wings.DropConnections()
+
+	select {
+	case <-s.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("session did not give up")
+	}
+	if s.Err() == nil || s.Err() == ErrClosed {
+		t.Errorf("expected a reconnect error, got %v", s.Err())
+	}
+	if _, ok := <-sub.C; ok {
+		t.Error("expected subscription to be closed")
+	}
+	if err = s.SendCommand("list"); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestSession_Close(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	s, err := OpenSession(context.Background(), &rotatingDetails{wings: wings})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub := s.Subscribe()
+	s.Close()
+
+	if _, ok := <-sub.C; ok {
+		t.Error("expected subscription to be closed")
+	}
+	if s.Err() != ErrClosed {
+		t.Errorf("expected ErrClosed, got %v", s.Err())
+	}
+}
+
+func TestSession_RejectedReconnect(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+	wings.AcceptToken = func(token string) bool { return token == "token-1" }
+
+	p := &rotatingDetails{wings: wings}
+	s, err := OpenSession(context.Background(), p,
+		WithReconnectBackoff(time.Millisecond, time.Millisecond),
+		WithMaxReconnectAttempts(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub := s.Subscribe()
+
+	wings.DropConnections()
+
+	select {
+	case <-s.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("session did not give up")
+	}
+	if s.Err() == nil || s.Err() == ErrClosed {
+		t.Errorf("expected a reconnect error, got %v", s.Err())
+	}
+	for ev := range sub.C {
+		if ev.Name == EventJWTError {
+			t.Errorf("rejected connection leaked %+v to the session", ev)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package console
+
+import (
+	"context"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"time"
+)
+
+// StatsSource is satisfied by clientapi.ServersService.
+type StatsSource interface {
+	DetailsProvider
+	GetResources(ctx context.Context) (*api.Resources, error)
+}
+
+// WithPollInterval sets how often WatchStats polls GetResources when no
+// websocket is available (default 10s).
+func WithPollInterval(d time.Duration) Option {
+	return func(c *config) { c.pollInterval = d }
+}
+
+// WithPollErrorHandler sets a callback for the GetResources errors WatchStats
+// runs into while polling.
+func WithPollErrorHandler(fn func(error)) Option {
+	return func(c *config) { c.pollErr = fn }
+}
+
+// ResourceAttributes converts a websocket stats payload into the shape
+// returned by GetResources.
+func (s *Stats) ResourceAttributes() api.ResourceAttributes {
+	var attrs api.ResourceAttributes
+	attrs.CurrentState = s.State
+	attrs.Resources.MemoryBytes = int(s.MemoryBytes)
+	attrs.Resources.CPUAbsolute = s.CPUAbsolute
+	attrs.Resources.DiskBytes = int(s.DiskBytes)
+	attrs.Resources.NetworkRxBytes = int(s.Network.RxBytes)
+	attrs.Resources.NetworkTxBytes = int(s.Network.TxBytes)
+	attrs.Resources.Uptime = s.Uptime
+	return attrs
+}
+
+// WatchStats streams the server's resource usage on the returned channel
+// until ctx is cancelled, at which point the channel is closed.
+//
+// Usage is pushed by Wings over a console Session, so watching many servers
+// costs one websocket each instead of a panel request per poll. While the
+// session is reconnecting WatchStats polls GetResources instead, and if the
+// socket cannot be established, or the session gives up reconnecting, it
+// falls back to polling for good. Polling errors go to the handler set with
+// WithPollErrorHandler; when polling is the only source, the channel is
+// closed after maxPollFailures of them in a row.
+//
+// The channel holds only the latest snapshot: a slow reader skips
+// intermediate values rather than stalling the stream.
+func WatchStats(ctx context.Context, src StatsSource, opts ...Option) <-chan api.ResourceAttributes {
+	cfg := newConfig(opts)
+	out := make(chan api.ResourceAttributes, 1)
+
+	go func() {
+		defer close(out)
+
+		p := &poller{src: src, onError: cfg.pollErr, out: out}
+		if s, err := OpenSession(ctx, src, opts...); err == nil {
+			streamStats(ctx, s, p, cfg.pollInterval)
+			s.Close()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		pollStats(ctx, p, cfg.pollInterval)
+	}()
+	return out
+}
+
+// streamStats forwards websocket stats until the session ends or ctx is
+// done, polling through p while the session is between connections.
+func streamStats(ctx context.Context, s *Session, p *poller, interval time.Duration) {
+	sub := s.Subscribe(EventStats, EventStatus)
+	defer sub.Close()
+	_ = s.RequestStats()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		last    api.ResourceAttributes
+		polling bool
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.connected() {
+				if polling {
+					// Back on the socket: ask for stats rather than wait
+					// for the next push.
+					polling = false
+					_ = s.RequestStats()
+				}
+				continue
+			}
+			polling = true
+			// The session may still recover, so failures are only reported.
+			p.poll(ctx)
+		case ev, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			switch ev.Name {
+			case EventStats:
+				stats, err := ev.Stats()
+				if err != nil {
+					continue
+				}
+				last = stats.ResourceAttributes()
+			case EventStatus:
+				// Wings stops sending stats once the server is offline, so
+				// surface state changes with the last known usage.
+				last.CurrentState = ev.Arg()
+			}
+			offer(p.out, last)
+		}
+	}
+}
+
+func pollStats(ctx context.Context, p *poller, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for p.poll(ctx) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// maxPollFailures is how many GetResources calls in a row may fail before
+// WatchStats gives up polling.
+const maxPollFailures = 5
+
+// poller fetches usage from the panel for WatchStats.
+type poller struct {
+	src      StatsSource
+	onError  func(error)
+	out      chan api.ResourceAttributes
+	failures int
+}
+
+// poll offers the current usage. It reports false once maxPollFailures calls
+// in a row have failed.
+func (p *poller) poll(ctx context.Context) bool {
+	res, err := p.src.GetResources(ctx)
+	if err == nil {
+		p.failures = 0
+		offer(p.out, res.Attributes)
+		return true
+	}
+	if ctx.Err() != nil {
+		return true
+	}
+	if p.onError != nil {
+		p.onError(err)
+	}
+	p.failures++
+	return p.failures < maxPollFailures
+}
+
+// offer replaces any unread value in out with v.
+func offer(out chan api.ResourceAttributes, v api.ResourceAttributes) {
+	for {
+		select {
+		case out <- v:
+			return
+		default:
+		}
+		select {
+		case <-out:
+		default:
+		}
+	}
+}
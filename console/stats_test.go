@@ -0,0 +1,173 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/internal/testutil"
+)
+
+type fakeStatsSource struct {
+	rotatingDetails
+	resMu     sync.Mutex
+	resources int
+	resErr    error
+}
+
+func (f *fakeStatsSource) GetResources(ctx context.Context) (*api.Resources, error) {
+	f.resMu.Lock()
+	defer f.resMu.Unlock()
+	if f.resErr != nil {
+		return nil, f.resErr
+	}
+	f.resources++
+	res := &api.Resources{Object: "stats"}
+	res.Attributes.CurrentState = "running"
+	res.Attributes.Resources.MemoryBytes = 512 * f.resources
+	return res, nil
+}
+
+func receiveStats(t *testing.T, ch <-chan api.ResourceAttributes) api.ResourceAttributes {
+	t.Helper()
+	select {
+	case attrs, ok := <-ch:
+		if !ok {
+			t.Fatal("stats channel closed unexpectedly")
+		}
+		return attrs
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for stats")
+	}
+	return api.ResourceAttributes{}
+}
+
+func TestWatchStats_Websocket(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src := &fakeStatsSource{rotatingDetails: rotatingDetails{wings: wings}}
+	stats := WatchStats(ctx, src)
+
+	receiveFrame(t, wings.Received, EventSendStats)
+	wings.Emit(EventStats, `{"memory_bytes":2048,"cpu_absolute":50.5,"disk_bytes":4096,"network":{"rx_bytes":1,"tx_bytes":2},"uptime":60000,"state":"running"}`)
+
+	attrs := receiveStats(t, stats)
+	if attrs.CurrentState != "running" || attrs.Resources.MemoryBytes != 2048 ||
+		attrs.Resources.CPUAbsolute != 50.5 || attrs.Resources.NetworkTxBytes != 2 || attrs.Resources.Uptime != 60000 {
+		t.Errorf("unexpected attributes %+v", attrs)
+	}
+
+	wings.Emit(EventStatus, "offline")
+	attrs = receiveStats(t, stats)
+	if attrs.CurrentState != "offline" || attrs.Resources.MemoryBytes != 2048 {
+		t.Errorf("expected offline state with last usage, got %+v", attrs)
+	}
+
+	if src.resources != 0 {
+		t.Errorf("expected no polling while the socket is up, got %d calls", src.resources)
+	}
+
+	cancel()
+	for range stats {
+	}
+}
+
+func TestWatchStats_PollingFallback(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src := &fakeStatsSource{rotatingDetails: rotatingDetails{wings: wings, err: fmt.Errorf("no websocket permission")}}
+	stats := WatchStats(ctx, src, WithPollInterval(5*time.Millisecond))
+
+	first := receiveStats(t, stats)
+	second := receiveStats(t, stats)
+	if first.CurrentState != "running" {
+		t.Errorf("unexpected state %q", first.CurrentState)
+	}
+	if second.Resources.MemoryBytes <= first.Resources.MemoryBytes {
+		t.Errorf("expected fresh snapshots, got %d then %d", first.Resources.MemoryBytes, second.Resources.MemoryBytes)
+	}
+
+	cancel()
+	for range stats {
+	}
+}
+
+func TestWatchStats_PollsWhileReconnecting(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+	var rejecting int32
+	wings.AcceptToken = func(string) bool { return atomic.LoadInt32(&rejecting) == 0 }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := &fakeStatsSource{rotatingDetails: rotatingDetails{wings: wings}}
+	stats := WatchStats(ctx, src, WithPollInterval(5*time.Millisecond),
+		WithReconnectBackoff(time.Millisecond, 5*time.Millisecond))
+	receiveFrame(t, wings.Received, EventSendStats)
+
+	atomic.StoreInt32(&rejecting, 1)
+	wings.DropConnections()
+	if attrs := receiveStats(t, stats); attrs.Resources.MemoryBytes == 0 {
+		t.Errorf("expected polled usage while reconnecting, got %+v", attrs)
+	}
+
+	atomic.StoreInt32(&rejecting, 0)
+	receiveFrame(t, wings.Received, EventSendStats)
+	wings.Emit(EventStats, `{"memory_bytes":7,"state":"running"}`)
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case attrs := <-stats:
+			if attrs.Resources.MemoryBytes == 7 {
+				cancel()
+				for range stats {
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("websocket stats did not resume")
+		}
+	}
+}
+
+func TestWatchStats_PollingErrors(t *testing.T) {
+	wings := testutil.NewWingsServer()
+	defer wings.Close()
+
+	src := &fakeStatsSource{
+		rotatingDetails: rotatingDetails{wings: wings, err: fmt.Errorf("no websocket permission")},
+		resErr:          fmt.Errorf("invalid API key"),
+	}
+	var (
+		mu     sync.Mutex
+		errors []error
+	)
+	stats := WatchStats(context.Background(), src, WithPollInterval(time.Millisecond),
+		WithPollErrorHandler(func(err error) {
+			mu.Lock()
+			errors = append(errors, err)
+			mu.Unlock()
+		}))
+
+	select {
+	case _, ok := <-stats:
+		if ok {
+			t.Fatal("expected no stats")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("polling did not give up")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errors) != maxPollFailures {
+		t.Errorf("expected %d errors, got %d", maxPollFailures, len(errors))
+	}
+}
@@ -0,0 +1,102 @@
+package console
+
+import "sync"
+
+// Subscription delivers the events a caller subscribed to on C. C is closed
+// once the underlying connection (or session) terminates.
+type Subscription struct {
+	C <-chan Event
+
+	ch    chan Event
+	names map[string]bool // nil means every event
+	done  chan struct{}
+	once  sync.Once
+	hub   *hub
+}
+
+// Close stops delivery to the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.hub.remove(s)
+	})
+}
+
+func (s *Subscription) wants(name string) bool {
+	return s.names == nil || s.names[name]
+}
+
+// hub fans incoming events out to every live subscription. publish and close
+// must only ever be called from one goroutine at a time (the active read
+// loop), which is what makes closing the subscriber channels safe.
+type hub struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	buffer int
+	closed bool
+}
+
+func newHub(buffer int) *hub {
+	return &hub{subs: make(map[*Subscription]struct{}), buffer: buffer}
+}
+
+func (h *hub) subscribe(names ...string) *Subscription {
+	ch := make(chan Event, h.buffer)
+	s := &Subscription{C: ch, ch: ch, done: make(chan struct{}), hub: h}
+	if len(names) > 0 {
+		s.names = make(map[string]bool, len(names))
+		for _, n := range names {
+			s.names[n] = true
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return s
+	}
+	h.subs[s] = struct{}{}
+	return s
+}
+
+func (h *hub) remove(s *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, s)
+	h.mu.Unlock()
+}
+
+// publish blocks until every interested subscriber has accepted ev, closed
+// its subscription, or stop is closed.
+func (h *hub) publish(ev Event, stop <-chan struct{}) {
+	h.mu.Lock()
+	targets := make([]*Subscription, 0, len(h.subs))
+	for s := range h.subs {
+		if s.wants(ev.Name) {
+			targets = append(targets, s)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, s := range targets {
+		select {
+		case s.ch <- ev:
+		case <-s.done:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for s := range h.subs {
+		close(s.ch)
+	}
+	h.subs = nil
+}
@@ -0,0 +1,242 @@
+package pterodactyl_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// fakeWings serves content through one-time signed URLs handed out by a fake
+// panel.
+type fakeWings struct {
+	content []byte
+	// cutAt truncates the first transfer after that many bytes.
+	cutAt int
+	// ranges makes Wings honour Range headers.
+	ranges bool
+
+	mu     sync.Mutex
+	issued int
+	used   map[string]bool
+	seen   []http.Header
+}
+
+func (f *fakeWings) servers(t *testing.T) (panel, wings *httptest.Server) {
+	wings = httptest.NewServer(http.HandlerFunc(f.serveWings))
+	t.Cleanup(wings.Close)
+	panel = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/client/servers/abc/files/download" {
+			http.NotFound(w, r)
+			return
+		}
+		f.mu.Lock()
+		f.issued++
+		token := strconv.Itoa(f.issued)
+		f.mu.Unlock()
+		fmt.Fprintf(w, `{"object":"signed_url","attributes":{"url":"%s/download/file?token=%s"}}`, wings.URL, token)
+	}))
+	t.Cleanup(panel.Close)
+	return panel, wings
+}
+
+func (f *fakeWings) serveWings(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	token := r.URL.Query().Get("token")
+	reused := f.used[token]
+	f.used[token] = true
+	f.seen = append(f.seen, r.Header.Clone())
+	first := len(f.seen) == 1
+	f.mu.Unlock()
+
+	if reused {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"token already used"}`))
+		return
+	}
+
+	body := f.content
+	if rng := r.Header.Get("Range"); f.ranges && rng != "" {
+		start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(body)-1, len(body)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)-start))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(body[start:])
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if first && f.cutAt > 0 {
+		// Declaring the full length and writing less makes the server drop
+		// the connection, which the client sees as an unexpected EOF.
+		_, _ = w.Write(body[:f.cutAt])
+		w.(http.Flusher).Flush()
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+func testContent(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+func TestFiles_DownloadTo_Resume(t *testing.T) {
+	t.Parallel()
+
+	for _, ranges := range []bool{true, false} {
+		ranges := ranges
+		t.Run(fmt.Sprintf("ranges=%v", ranges), func(t *testing.T) {
+			t.Parallel()
+			f := &fakeWings{content: testContent(200_000), cutAt: 70_000, ranges: ranges, used: map[string]bool{}}
+			panel, _ := f.servers(t)
+			c, err := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var buf bytes.Buffer
+			var last, total int64
+			n, err := c.ClientAPI.Servers("abc").Files().DownloadTo(context.Background(), "/world.zip", &buf, api.DownloadOptions{
+				Progress: func(downloaded, size int64) { last, total = downloaded, size },
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != int64(len(f.content)) || !bytes.Equal(buf.Bytes(), f.content) {
+				t.Fatalf("expected the full file, got %d bytes", n)
+			}
+			if last != int64(len(f.content)) || total != int64(len(f.content)) {
+				t.Errorf("expected final progress %d/%d, got %d/%d", len(f.content), len(f.content), last, total)
+			}
+			if f.issued != 2 {
+				t.Errorf("expected a new signed URL for the resumption, got %d", f.issued)
+			}
+			if got := f.seen[1].Get("Range"); got != "bytes=70000-" {
+				t.Errorf("expected the resumption to request the rest of the file, got Range %q", got)
+			}
+			for _, h := range f.seen {
+				if h.Get("Authorization") != "" {
+					t.Error("the panel key was sent to Wings")
+				}
+			}
+		})
+	}
+}
+
+func TestFiles_DownloadTo_NoResume(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeWings{content: testContent(50_000), cutAt: 10_000, used: map[string]bool{}}
+	panel, _ := f.servers(t)
+	c, _ := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey)
+
+	var buf bytes.Buffer
+	n, err := c.ClientAPI.Servers("abc").Files().DownloadTo(context.Background(), "/world.zip", &buf, api.DownloadOptions{MaxResumes: -1})
+	if err == nil {
+		t.Fatal("expected the interrupted transfer to fail")
+	}
+	if n != 10_000 || buf.Len() != 10_000 {
+		t.Errorf("expected the bytes received before the interruption to be reported, got %d", n)
+	}
+}
+
+func TestFiles_DownloadTo_Offset(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeWings{content: testContent(30_000), ranges: true, used: map[string]bool{}}
+	panel, _ := f.servers(t)
+	c, _ := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey)
+
+	var buf bytes.Buffer
+	n, err := c.ClientAPI.Servers("abc").Files().DownloadTo(context.Background(), "/world.zip", &buf, api.DownloadOptions{Offset: 12_000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 18_000 || !bytes.Equal(buf.Bytes(), f.content[12_000:]) {
+		t.Errorf("expected the rest of the file, got %d bytes", n)
+	}
+}
+
+func TestFiles_Open(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeWings{content: testContent(64_000), used: map[string]bool{}}
+	panel, _ := f.servers(t)
+	c, _ := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey)
+
+	rc, err := c.ClientAPI.Servers("abc").Files().Open(context.Background(), "/world.zip")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, f.content) {
+		t.Errorf("expected %d bytes, got %d", len(f.content), len(got))
+	}
+}
+
+func TestFiles_GetContents(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("file") != "/server.properties" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("motd=hello\n"))
+	}))
+
+	got, err := c.ClientAPI.Servers("abc").Files().GetContents(context.Background(), "/server.properties")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "motd=hello\n" {
+		t.Errorf("expected the file contents, got %q", got)
+	}
+}
+
+func TestClient_Stream_TimeoutOnlyCoversHeaders(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 4; i++ {
+			_, _ = w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(40 * time.Millisecond)
+		}
+	}), pterodactyl.WithTimeout(50*time.Millisecond))
+
+	req, err := c.NewRequest(context.Background(), "GET", "/api/client/servers/abc/files/contents?file=big.log", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := c.Stream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("expected the slow body to be read past the client timeout, got %v", err)
+	}
+	if string(body) != strings.Repeat("chunk", 4) {
+		t.Errorf("unexpected body %q", body)
+	}
+}
@@ -1,19 +1,32 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 )
 
 type PterodactylError struct {
-	Code   string `json:"code"`
-	Status string `json:"status"`
-	Detail string `json:"detail"`
+	Code   string     `json:"code"`
+	Status string     `json:"status"`
+	Detail string     `json:"detail"`
+	Meta   *ErrorMeta `json:"meta,omitempty"`
+}
+
+// ErrorMeta is attached to validation errors and names the offending input.
+type ErrorMeta struct {
+	SourceField string `json:"source_field"`
+	Rule        string `json:"rule"`
 }
 
 type APIError struct {
 	HTTPStatusCode int
 	Errors         []PterodactylError
+	// RawBody holds the response body when it was not a Pterodactyl error
+	// document, e.g. an HTML page from a reverse proxy.
+	RawBody []byte `json:"-"`
 }
 
 // Error implements the error interface, providing a user-friendly error message.
@@ -22,6 +35,177 @@ func (e *APIError) Error() string {
 	for _, pErr := range e.Errors {
 		errorDetails = append(errorDetails, pErr.Detail)
 	}
+	if len(errorDetails) == 0 && len(e.RawBody) > 0 {
+		body := strings.TrimSpace(string(e.RawBody))
+		if len(body) > 200 {
+			body = body[:200] + "…"
+		}
+		errorDetails = append(errorDetails, body)
+	}
 	return fmt.Sprintf("pterodactyl: API error (status %d): %s",
 		e.HTTPStatusCode, strings.Join(errorDetails, ", "))
 }
+
+// Sentinel errors matched by APIError.Is, so callers can write
+//
+//	if errors.Is(err, pterrors.ErrNotFound) { … }
+//
+// instead of inspecting status codes or error details.
+var (
+	ErrNotFound        = stderrors.New("pterodactyl: resource not found")
+	ErrValidation      = stderrors.New("pterodactyl: validation failed")
+	ErrConflict        = stderrors.New("pterodactyl: conflict")
+	ErrRateLimited     = stderrors.New("pterodactyl: rate limited")
+	ErrUnauthorized    = stderrors.New("pterodactyl: unauthorized")
+	ErrServerSuspended = stderrors.New("pterodactyl: server suspended")
+	ErrDaemon          = stderrors.New("pterodactyl: daemon error")
+)
+
+// Is reports whether the error belongs to the category of target, judged by
+// the HTTP status and the Pterodactyl exception codes in the response.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPStatusCode == http.StatusNotFound ||
+			e.hasCode("NotFoundHttpException", "ModelNotFoundException", "RecordNotFoundException")
+	case ErrValidation:
+		return e.HTTPStatusCode == http.StatusUnprocessableEntity ||
+			e.hasCode("ValidationException", "DataValidationException")
+	case ErrConflict:
+		return e.HTTPStatusCode == http.StatusConflict ||
+			e.hasCode("ConflictHttpException", "ServerStateConflictException")
+	case ErrRateLimited:
+		return e.HTTPStatusCode == http.StatusTooManyRequests ||
+			e.hasCode("TooManyRequestsHttpException", "ThrottleRequestsException")
+	case ErrUnauthorized:
+		return e.HTTPStatusCode == http.StatusUnauthorized || e.HTTPStatusCode == http.StatusForbidden ||
+			e.hasCode("AuthenticationException", "UnauthorizedHttpException", "AccessDeniedHttpException")
+	case ErrServerSuspended:
+		for _, pErr := range e.Errors {
+			if pErr.Code == "ServerStateConflictException" && strings.Contains(strings.ToLower(pErr.Detail), "suspended") {
+				return true
+			}
+		}
+		return false
+	case ErrDaemon:
+		return e.hasCode("DaemonConnectionException")
+	}
+	return false
+}
+
+// As lets errors.As extract a *ValidationError from a validation failure.
+func (e *APIError) As(target any) bool {
+	v, ok := target.(**ValidationError)
+	if !ok || !e.Is(ErrValidation) {
+		return false
+	}
+	*v = e.Validation()
+	return true
+}
+
+// Validation groups the per-field entries of a validation failure. Entries
+// without a source field are collected under the empty key.
+func (e *APIError) Validation() *ValidationError {
+	v := &ValidationError{Fields: make(map[string][]FieldError), apiErr: e}
+	for _, pErr := range e.Errors {
+		field, rule := "", ""
+		if pErr.Meta != nil {
+			field, rule = pErr.Meta.SourceField, pErr.Meta.Rule
+		}
+		v.Fields[field] = append(v.Fields[field], FieldError{Rule: rule, Detail: pErr.Detail})
+	}
+	return v
+}
+
+func (e *APIError) hasCode(codes ...string) bool {
+	for _, pErr := range e.Errors {
+		for _, code := range codes {
+			if pErr.Code == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an API error for a missing resource.
+func IsNotFound(err error) bool { return stderrors.Is(err, ErrNotFound) }
+
+// IsValidation reports whether the panel rejected the request payload.
+func IsValidation(err error) bool { return stderrors.Is(err, ErrValidation) }
+
+// IsConflict reports whether the request conflicts with the resource's state.
+func IsConflict(err error) bool { return stderrors.Is(err, ErrConflict) }
+
+// IsRateLimited reports whether the API key exceeded its request quota.
+func IsRateLimited(err error) bool { return stderrors.Is(err, ErrRateLimited) }
+
+// IsUnauthorized reports whether the key was rejected or lacks permission.
+func IsUnauthorized(err error) bool { return stderrors.Is(err, ErrUnauthorized) }
+
+// IsServerSuspended reports whether the target server is suspended.
+func IsServerSuspended(err error) bool { return stderrors.Is(err, ErrServerSuspended) }
+
+// IsDaemonError reports whether the panel could not reach Wings.
+func IsDaemonError(err error) bool { return stderrors.Is(err, ErrDaemon) }
+
+// FieldError is a single failed rule for one input field.
+type FieldError struct {
+	Rule   string
+	Detail string
+}
+
+// ValidationError maps each offending field to the rules it failed.
+//
+//	var verr *pterrors.ValidationError
+//	if errors.As(err, &verr) {
+//		for field, failures := range verr.Fields { … }
+//	}
+type ValidationError struct {
+	Fields map[string][]FieldError
+
+	apiErr *APIError
+}
+
+// Error lists the failures ordered by field name.
+func (v *ValidationError) Error() string {
+	fields := make([]string, 0, len(v.Fields))
+	for f := range v.Fields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	var parts []string
+	for _, f := range fields {
+		for _, fe := range v.Fields[f] {
+			if f == "" {
+				parts = append(parts, fe.Detail)
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s: %s", f, fe.Detail))
+		}
+	}
+	return "pterodactyl: validation failed: " + strings.Join(parts, "; ")
+}
+
+// Rules returns the names of the rules field failed.
+func (v *ValidationError) Rules(field string) []string {
+	var rules []string
+	for _, fe := range v.Fields[field] {
+		rules = append(rules, fe.Rule)
+	}
+	return rules
+}
+
+// Is makes every ValidationError match ErrValidation.
+func (v *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the APIError the failures were decoded from, if any.
+func (v *ValidationError) Unwrap() error {
+	if v.apiErr == nil {
+		return nil
+	}
+	return v.apiErr
+}
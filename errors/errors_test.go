@@ -0,0 +1,158 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	t.Parallel()
+
+	predicates := map[string]func(error) bool{
+		"IsNotFound":        IsNotFound,
+		"IsValidation":      IsValidation,
+		"IsConflict":        IsConflict,
+		"IsRateLimited":     IsRateLimited,
+		"IsUnauthorized":    IsUnauthorized,
+		"IsServerSuspended": IsServerSuspended,
+		"IsDaemonError":     IsDaemonError,
+	}
+
+	testCases := []struct {
+		name     string
+		err      *APIError
+		expected []string
+	}{
+		{
+			name: "not found",
+			err: &APIError{HTTPStatusCode: http.StatusNotFound, Errors: []PterodactylError{
+				{Code: "NotFoundHttpException", Status: "404", Detail: "The requested resource could not be found on the server."},
+			}},
+			expected: []string{"IsNotFound"},
+		},
+		{
+			name: "validation",
+			err: &APIError{HTTPStatusCode: http.StatusUnprocessableEntity, Errors: []PterodactylError{
+				{Code: "ValidationException", Status: "422", Detail: "The email has already been taken."},
+			}},
+			expected: []string{"IsValidation"},
+		},
+		{
+			name: "rate limited",
+			err: &APIError{HTTPStatusCode: http.StatusTooManyRequests, Errors: []PterodactylError{
+				{Code: "TooManyRequestsHttpException", Status: "429", Detail: "Too Many Attempts."},
+			}},
+			expected: []string{"IsRateLimited"},
+		},
+		{
+			name: "forbidden",
+			err: &APIError{HTTPStatusCode: http.StatusForbidden, Errors: []PterodactylError{
+				{Code: "AccessDeniedHttpException", Status: "403", Detail: "This action is unauthorized."},
+			}},
+			expected: []string{"IsUnauthorized"},
+		},
+		{
+			name: "suspended server",
+			err: &APIError{HTTPStatusCode: http.StatusConflict, Errors: []PterodactylError{
+				{Code: "ServerStateConflictException", Status: "409", Detail: "This server is currently suspended and the functionality requested is unavailable."},
+			}},
+			expected: []string{"IsConflict", "IsServerSuspended"},
+		},
+		{
+			name: "daemon unreachable",
+			err: &APIError{HTTPStatusCode: http.StatusBadGateway, Errors: []PterodactylError{
+				{Code: "DaemonConnectionException", Status: "502", Detail: "An error was encountered while processing this request."},
+			}},
+			expected: []string{"IsDaemonError"},
+		},
+		{
+			name: "code without matching status",
+			err: &APIError{HTTPStatusCode: http.StatusBadRequest, Errors: []PterodactylError{
+				{Code: "ModelNotFoundException", Status: "400"},
+			}},
+			expected: []string{"IsNotFound"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			want := make(map[string]bool)
+			for _, name := range tc.expected {
+				want[name] = true
+			}
+			// Wrapping must not hide the category.
+			wrapped := fmt.Errorf("create server: %w", tc.err)
+			for name, is := range predicates {
+				if got := is(wrapped); got != want[name] {
+					t.Errorf("%s: expected %v, got %v", name, want[name], got)
+				}
+			}
+		})
+	}
+}
+
+func TestPredicates_NonAPIError(t *testing.T) {
+	t.Parallel()
+
+	err := stderrors.New("dial tcp: connection refused")
+	if IsNotFound(err) || IsRateLimited(err) || IsDaemonError(err) {
+		t.Error("expected predicates to be false for non-API errors")
+	}
+	if IsNotFound(nil) {
+		t.Error("expected IsNotFound(nil) to be false")
+	}
+}
+
+func TestAPIError_Validation(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"errors":[
+		{"code":"ValidationException","status":"422","detail":"The email must be a valid email address.","meta":{"source_field":"email","rule":"email"}},
+		{"code":"ValidationException","status":"422","detail":"The email has already been taken.","meta":{"source_field":"email","rule":"unique"}},
+		{"code":"ValidationException","status":"422","detail":"The limits.memory field is required.","meta":{"source_field":"limits.memory","rule":"required"}}
+	]}`)
+	apiErr := &APIError{HTTPStatusCode: http.StatusUnprocessableEntity}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var verr *ValidationError
+	if !stderrors.As(fmt.Errorf("create user: %w", apiErr), &verr) {
+		t.Fatal("expected errors.As to find a ValidationError")
+	}
+	if rules := verr.Rules("email"); !reflect.DeepEqual(rules, []string{"email", "unique"}) {
+		t.Errorf("unexpected email rules %v", rules)
+	}
+	if failures := verr.Fields["limits.memory"]; len(failures) != 1 || failures[0].Detail != "The limits.memory field is required." {
+		t.Errorf("unexpected limits.memory failures %+v", failures)
+	}
+	if !stderrors.Is(verr, ErrValidation) {
+		t.Error("expected ValidationError to match ErrValidation")
+	}
+	if stderrors.Unwrap(verr) != apiErr {
+		t.Error("expected ValidationError to unwrap to the APIError")
+	}
+
+	t.Run("not a validation error", func(t *testing.T) {
+		var verr *ValidationError
+		if stderrors.As(&APIError{HTTPStatusCode: http.StatusNotFound}, &verr) {
+			t.Error("expected errors.As to fail for a 404")
+		}
+	})
+}
+
+func TestAPIError_RawBody(t *testing.T) {
+	t.Parallel()
+
+	err := &APIError{HTTPStatusCode: http.StatusBadGateway, RawBody: []byte("<html>502 Bad Gateway</html>\n")}
+	expected := "pterodactyl: API error (status 502): <html>502 Bad Gateway</html>"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
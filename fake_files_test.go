@@ -0,0 +1,196 @@
+package pterodactyl_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/clientapi"
+)
+
+// fakeFiles is an in-memory server filesystem behind the panel's file
+// endpoints and the Wings upload and download URLs they sign.
+type fakeFiles struct {
+	mu    sync.Mutex
+	files map[string]*fakeFile
+	// calls records mutating and download requests as "METHOD path".
+	calls []string
+	// lists counts directory listings.
+	lists int
+}
+
+type fakeFile struct {
+	dir  bool
+	data []byte
+	mod  time.Time
+	// symlink lists the file as a symlink whose target is missing.
+	symlink bool
+	// unreadable makes Wings refuse to read the file.
+	unreadable bool
+}
+
+func newFakeFiles(t *testing.T, files map[string]string, opts ...pterodactyl.Option) (*fakeFiles, clientapi.FileService) {
+	t.Helper()
+	f := &fakeFiles{files: map[string]*fakeFile{"/": {dir: true}}}
+	for name, data := range files {
+		f.write(name, []byte(data), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	c, err := pterodactyl.NewClient(srv.URL, "ptlc_test", pterodactyl.ClientKey, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return f, c.ClientAPI.Servers("abc").Files()
+}
+
+// write stores a file and creates its parent directories.
+func (f *fakeFiles) write(name string, data []byte, mod time.Time) {
+	name = path.Clean("/" + name)
+	for dir := path.Dir(name); dir != "/"; dir = path.Dir(dir) {
+		if f.files[dir] == nil {
+			f.files[dir] = &fakeFile{dir: true, mod: mod}
+		}
+	}
+	f.files[name] = &fakeFile{data: data, mod: mod}
+}
+
+// contents returns the files, excluding directories, keyed by path.
+func (f *fakeFiles) contents() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[string]string)
+	for name, file := range f.files {
+		if !file.dir {
+			out[name] = string(file.data)
+		}
+	}
+	return out
+}
+
+func (f *fakeFiles) listCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lists
+}
+
+func (f *fakeFiles) resetLists() {
+	f.mu.Lock()
+	f.lists = 0
+	f.mu.Unlock()
+}
+
+func (f *fakeFiles) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q := r.URL.Query()
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/api/client/servers/abc/files")
+	if r.Method != "GET" || strings.HasPrefix(route, "GET /download") {
+		f.calls = append(f.calls, route)
+	}
+	switch route {
+	case "GET /list":
+		f.lists++
+		dir := path.Clean("/" + q.Get("directory"))
+		if d := f.files[dir]; d == nil || !d.dir {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors":[{"code":"NotFoundHttpException","status":"404","detail":"not found"}]}`)
+			return
+		}
+		list := api.FileListResponse{Object: "list", Data: []*api.FileObjectResponse{}}
+		for _, name := range f.sortedNames() {
+			file := f.files[name]
+			if name == "/" || path.Dir(name) != dir {
+				continue
+			}
+			list.Data = append(list.Data, &api.FileObjectResponse{Object: "file_object", Attributes: &api.FileObject{
+				Name: path.Base(name), ModeBits: "644", Size: int64(len(file.data)), IsFile: !file.dir, IsSymlink: file.symlink, ModifiedAt: file.mod,
+			}})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	case "GET /contents":
+		file := f.files[path.Clean("/"+q.Get("file"))]
+		if file == nil || file.dir || file.symlink {
+			http.NotFound(w, r)
+			return
+		}
+		if file.unreadable {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(w, `{"errors":[{"code":"DaemonConnectionException","status":"502","detail":"permission denied"}]}`)
+			return
+		}
+		_, _ = w.Write(file.data)
+	case "GET /download":
+		_, _ = io.WriteString(w, `{"object":"signed_url","attributes":{"url":"http://`+r.Host+`/download/file?file=`+q.Get("file")+`"}}`)
+	case "GET /download/file":
+		file := f.files[path.Clean("/"+q.Get("file"))]
+		if file == nil || file.dir {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(file.data)
+	case "GET /upload":
+		_, _ = io.WriteString(w, `{"object":"signed_url","attributes":{"url":"http://`+r.Host+`/upload/file?token=t"}}`)
+	case "POST /upload/file":
+		mr, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			data, _ := io.ReadAll(part)
+			f.write(path.Join(q.Get("directory"), part.FileName()), data, time.Now())
+		}
+	case "POST /delete":
+		var options api.DeleteFilesOptions
+		_ = json.NewDecoder(r.Body).Decode(&options)
+		for _, name := range options.Files {
+			target := path.Clean("/" + path.Join(options.Root, name))
+			for existing := range f.files {
+				if existing == target || strings.HasPrefix(existing, target+"/") {
+					delete(f.files, existing)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "POST /create-folder":
+		var options api.CreateFolderOptions
+		_ = json.NewDecoder(r.Body).Decode(&options)
+		dir := path.Clean("/" + path.Join(options.Root, options.Name))
+		for ; dir != "/"; dir = path.Dir(dir) {
+			if f.files[dir] == nil {
+				f.files[dir] = &fakeFile{dir: true, mod: time.Now()}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeFiles) sortedNames() []string {
+	names := make([]string, 0, len(f.files))
+	for name := range f.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
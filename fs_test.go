@@ -0,0 +1,106 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+func TestFiles_FS(t *testing.T) {
+	t.Parallel()
+
+	_, files := newFakeFiles(t, map[string]string{
+		"/server.properties":        "motd=hi\n",
+		"/plugins/Essentials.jar":   "jar",
+		"/plugins/Essentials/a.yml": "a: 1\n",
+		"/plugins/Essentials/b.yml": "b: 2\n",
+		"/logs/latest.log":          "started\n",
+	})
+	fsys := files.FS(context.Background(), api.FSOptions{})
+
+	if err := fstest.TestFS(fsys, "server.properties", "plugins/Essentials.jar", "plugins/Essentials/a.yml", "logs/latest.log"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := fs.Stat(fsys, "plugins/Essentials.jar")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Size() != 3 || info.Mode() != 0o644 || !info.ModTime().Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected file info: size %d, mode %v, modified %v", info.Size(), info.Mode(), info.ModTime())
+	}
+	if _, ok := info.Sys().(*api.FileObject); !ok {
+		t.Errorf("expected Sys to return the file object, got %T", info.Sys())
+	}
+
+	matches, err := fs.Glob(fsys, "plugins/Essentials/*.yml")
+	if err != nil || !reflect.DeepEqual(matches, []string{"plugins/Essentials/a.yml", "plugins/Essentials/b.yml"}) {
+		t.Errorf("unexpected glob result %v, %v", matches, err)
+	}
+
+	if _, err := fs.Stat(fsys, "plugins/missing.jar"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+	if _, err := fs.ReadDir(fsys, "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestFiles_FS_Root(t *testing.T) {
+	t.Parallel()
+
+	_, files := newFakeFiles(t, map[string]string{"/plugins/Essentials/config.yml": "motd: hi"})
+	fsys := files.FS(context.Background(), api.FSOptions{Root: "/plugins"})
+
+	data, err := fs.ReadFile(fsys, "Essentials/config.yml")
+	if err != nil || string(data) != "motd: hi" {
+		t.Errorf("expected the file below the root, got %q, %v", data, err)
+	}
+}
+
+func TestFiles_FS_Cache(t *testing.T) {
+	t.Parallel()
+
+	fake, files := newFakeFiles(t, map[string]string{
+		"/a/1.txt": "1",
+		"/a/2.txt": "2",
+		"/b/3.txt": "3",
+	})
+	walk := func(fsys fs.FS) {
+		t.Helper()
+		err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			_, err = fs.Stat(fsys, p)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	cached := files.FS(context.Background(), api.FSOptions{CacheTTL: time.Minute})
+	walk(cached)
+	walk(cached)
+	if fake.listCount() != 3 {
+		t.Errorf("expected each directory to be listed once, got %d listings", fake.listCount())
+	}
+	cached.ClearCache()
+	walk(cached)
+	if fake.listCount() != 6 {
+		t.Errorf("expected the cleared cache to be refilled, got %d listings", fake.listCount())
+	}
+
+	fake.resetLists()
+	walk(files.FS(context.Background(), api.FSOptions{}))
+	if fake.listCount() <= 3 {
+		t.Errorf("expected listings to be repeated without a cache, got %d", fake.listCount())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/davidarkless/go-pterodactyl/api"
 	"github.com/davidarkless/go-pterodactyl/internal/requester"
+	"sync"
 )
 
 func List[T any](ctx context.Context, c requester.Requester, path string,
@@ -27,38 +28,152 @@ func List[T any](ctx context.Context, c requester.Requester, path string,
 	return out, &resp.Meta, nil
 }
 
+// ListAll fetches every page of path. Once the first page reveals the page
+// count, the remaining pages are fetched by up to
+// requester.ListConcurrency(c) workers; the result keeps the panel's order.
 func ListAll[T any](
 	ctx context.Context,
 	c requester.Requester,
 	path string,
 	perPage int,
 ) ([]*T, error) {
+	return ListAllFunc[T](ctx, c, perPage, func(ctx context.Context, o *api.PaginationOptions) ([]*T, *api.Meta, error) {
+		return List[T](ctx, c, path, o)
+	})
+}
+
+// ListAllFunc is ListAll for services that decode their own list responses.
+// The first error cancels the requests still in flight and is returned.
+func ListAllFunc[T any](ctx context.Context, c requester.Requester, perPage int,
+	list func(ctx context.Context, o *api.PaginationOptions) ([]*T, *api.Meta, error)) ([]*T, error) {
 
 	if perPage <= 0 {
 		perPage = 100
 	}
 
-	all := make([]*T, 0, perPage)
-	opts := &api.PaginationOptions{PerPage: perPage, Page: 1}
+	first, meta, err := list(ctx, &api.PaginationOptions{PerPage: perPage, Page: 1})
+	if err != nil {
+		return nil, err
+	}
+	totalPages := meta.Pagination.TotalPages
+	if totalPages <= 1 || len(first) == 0 {
+		return first, nil
+	}
 
-	for {
-		items, meta, err := List[T](ctx, c, path, opts)
-		if err != nil {
-			return nil, err
+	workers := requester.ListConcurrency(c)
+	if workers <= 1 {
+		all := append(make([]*T, 0, len(first)*totalPages), first...)
+		for page := 2; page <= totalPages; page++ {
+			items, meta, err := list(ctx, &api.PaginationOptions{PerPage: perPage, Page: page})
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, items...)
+			if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+				break
+			}
 		}
-		all = append(all, items...)
+		return all, nil
+	}
+	if workers > totalPages-1 {
+		workers = totalPages - 1
+	}
 
-		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
-			break
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]*T, totalPages)
+	pages[0] = first
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range jobs {
+				items, _, err := list(ctx, &api.PaginationOptions{PerPage: perPage, Page: page})
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				pages[page-1] = items
+			}
+		}()
+	}
+
+feed:
+	for page := 2; page <= totalPages; page++ {
+		select {
+		case jobs <- page:
+		case <-ctx.Done():
+			break feed
 		}
-		opts.Page++
 	}
+	close(jobs)
+	wg.Wait()
 
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	n := 0
+	for _, items := range pages {
+		n += len(items)
+	}
+	all := make([]*T, 0, n)
+	for _, items := range pages {
+		all = append(all, items...)
+	}
 	return all, nil
 }
 
-func Get[T any](ctx context.Context, c requester.Requester, path string, id int) (*T, error) {
-	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%d", path, id), nil, nil)
+// Iterate returns an iterator over path that fetches one page at a time.
+// opt may be nil; its Page is the first page fetched and its PerPage
+// defaults to 100.
+func Iterate[T any](ctx context.Context, c requester.Requester, path string, opt *api.PaginationOptions) *api.Iterator[T] {
+	return IterateFunc[T](ctx, opt, func(ctx context.Context, o *api.PaginationOptions) ([]*T, *api.Meta, error) {
+		return List[T](ctx, c, path, o)
+	})
+}
+
+// IterateFunc is Iterate for services that decode their own list responses.
+// Each page is requested through list with a copy of opt, so callers may
+// reuse it.
+func IterateFunc[T any](ctx context.Context, opt *api.PaginationOptions,
+	list func(ctx context.Context, o *api.PaginationOptions) ([]*T, *api.Meta, error)) *api.Iterator[T] {
+
+	base := api.PaginationOptions{}
+	if opt != nil {
+		base = *opt
+	}
+	if base.PerPage <= 0 {
+		base.PerPage = 100
+	}
+	return api.NewIterator[T](ctx, base.Page, func(ctx context.Context, page int) ([]*T, *api.Meta, error) {
+		o := base
+		o.Page = page
+		return list(ctx, &o)
+	})
+}
+
+// Get fetches a single resource, asking the panel to include the given
+// relationships.
+func Get[T any](ctx context.Context, c requester.Requester, path string, id int, include ...string) (*T, error) {
+	var opts *api.PaginationOptions
+	if len(include) > 0 {
+		opts = &api.PaginationOptions{Include: include}
+	}
+	req, err := c.NewRequest(ctx, "GET", fmt.Sprintf("%s/%d", path, id), nil, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -10,4 +10,24 @@ import (
 type Requester interface {
 	NewRequest(ctx context.Context, method, endpoint string, body io.Reader, options *api.PaginationOptions) (*http.Request, error)
 	Do(ctx context.Context, req *http.Request, v any) (*http.Response, error)
+	// Stream is like Do but leaves the body of a successful response open
+	// for the caller to read and close.
+	Stream(ctx context.Context, req *http.Request) (*http.Response, error)
+}
+
+// ConcurrencyHinter is optionally implemented by a Requester to let list
+// helpers fetch several pages at once. ListConcurrency returns the maximum
+// number of page requests to keep in flight; 1 or less means sequential.
+type ConcurrencyHinter interface {
+	ListConcurrency() int
+}
+
+// ListConcurrency returns the hint of c, or 1 if it gives none.
+func ListConcurrency(c Requester) int {
+	if h, ok := c.(ConcurrencyHinter); ok {
+		if n := h.ListConcurrency(); n > 1 {
+			return n
+		}
+	}
+	return 1
 }
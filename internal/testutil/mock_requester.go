@@ -9,6 +9,7 @@ import (
 	"github.com/davidarkless/go-pterodactyl/errors"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // mockRequester implements the requester.Requester interface for testing
@@ -44,11 +45,20 @@ func (m *MockRequester) NewRequest(ctx context.Context, method, endpoint string,
 		Options:  options,
 	})
 
-	// Create a minimal request for testing
-	req, _ := http.NewRequestWithContext(ctx, method, "http://test.com"+endpoint, bytes.NewReader(bodyBytes))
+	// Create a minimal request for testing. Signed Wings URLs are absolute.
+	target := "http://test.com" + endpoint
+	if strings.Contains(endpoint, "://") {
+		target = endpoint
+	}
+	req, _ := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(bodyBytes))
 	return req, nil
 }
 
+// Stream returns the next response like Do, with its body left readable.
+func (m *MockRequester) Stream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return m.Do(ctx, req, nil)
+}
+
 func (m *MockRequester) Do(ctx context.Context, req *http.Request, v any) (*http.Response, error) {
 	if m.CurrentIndex >= len(m.Responses) {
 		return nil, fmt.Errorf("no more mock Responses available")
@@ -78,9 +88,9 @@ func (m *MockRequester) Do(ctx context.Context, req *http.Request, v any) (*http
 	if response.StatusCode >= 400 {
 		apiErr := &errors.APIError{HTTPStatusCode: response.StatusCode}
 		if len(response.Body) > 0 {
-			err := json.NewDecoder(bytes.NewReader(response.Body)).Decode(apiErr)
-			if err != nil {
-				return nil, err
+			if err := json.Unmarshal(response.Body, apiErr); err != nil || len(apiErr.Errors) == 0 {
+				apiErr.Errors = nil
+				apiErr.RawBody = response.Body
 			}
 		}
 		return nil, apiErr
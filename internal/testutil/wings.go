@@ -0,0 +1,154 @@
+package testutil
+
+import (
+	"encoding/json"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// WingsEvent mirrors a single frame of the Wings websocket protocol.
+type WingsEvent struct {
+	Event string   `json:"event"`
+	Args  []string `json:"args,omitempty"`
+}
+
+// WingsServer is a local stand-in for the Wings websocket endpoint. It
+// performs the "auth" handshake, records every frame it receives and lets
+// tests push events to the connected clients.
+type WingsServer struct {
+	Server *httptest.Server
+
+	// Received gets a copy of every frame sent by a client.
+	Received chan WingsEvent
+
+	// AcceptToken decides whether an "auth" token is valid. Nil accepts all.
+	AcceptToken func(token string) bool
+
+	// Respond, if set, is called for every non-auth frame; the returned
+	// events are written back to the sending connection.
+	Respond func(ev WingsEvent) []WingsEvent
+
+	mu    sync.Mutex
+	conns map[*websocket.Conn]*sync.Mutex
+	dials int
+}
+
+// NewWingsServer starts a fake Wings websocket server. Callers must Close it.
+func NewWingsServer() *WingsServer {
+	w := &WingsServer{
+		Received: make(chan WingsEvent, 256),
+		conns:    make(map[*websocket.Conn]*sync.Mutex),
+	}
+	w.Server = httptest.NewServer(http.HandlerFunc(w.serve))
+	return w
+}
+
+// URL returns the ws:// address of the server.
+func (w *WingsServer) URL() string {
+	return "ws" + strings.TrimPrefix(w.Server.URL, "http")
+}
+
+// Details builds the websocket details the panel would hand out.
+func (w *WingsServer) Details(token string) *api.WebsocketDetails {
+	return &api.WebsocketDetails{Token: token, SocketURL: w.URL()}
+}
+
+// Dials reports how many websocket connections have been accepted so far.
+func (w *WingsServer) Dials() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dials
+}
+
+// Emit broadcasts an event to every authenticated connection.
+func (w *WingsServer) Emit(event string, args ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ws, mu := range w.conns {
+		write(ws, mu, WingsEvent{Event: event, Args: args})
+	}
+}
+
+// DropConnections abruptly closes every open connection, simulating a
+// network failure.
+func (w *WingsServer) DropConnections() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ws := range w.conns {
+		ws.Close()
+		delete(w.conns, ws)
+	}
+}
+
+// Close drops all connections and shuts the HTTP server down.
+func (w *WingsServer) Close() {
+	w.DropConnections()
+	w.Server.Close()
+}
+
+func (w *WingsServer) serve(rw http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	ws, err := upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	w.dials++
+	w.mu.Unlock()
+
+	mu := &sync.Mutex{}
+	defer func() {
+		w.mu.Lock()
+		delete(w.conns, ws)
+		w.mu.Unlock()
+		ws.Close()
+	}()
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var ev WingsEvent
+		if err = json.Unmarshal(data, &ev); err != nil {
+			continue
+		}
+		select {
+		case w.Received <- ev:
+		default:
+		}
+
+		if ev.Event == "auth" {
+			token := ""
+			if len(ev.Args) > 0 {
+				token = ev.Args[0]
+			}
+			if w.AcceptToken != nil && !w.AcceptToken(token) {
+				write(ws, mu, WingsEvent{Event: "jwt error", Args: []string{"invalid token"}})
+				continue
+			}
+			w.mu.Lock()
+			w.conns[ws] = mu
+			w.mu.Unlock()
+			write(ws, mu, WingsEvent{Event: "auth success"})
+			continue
+		}
+
+		if w.Respond != nil {
+			for _, reply := range w.Respond(ev) {
+				write(ws, mu, reply)
+			}
+		}
+	}
+}
+
+func write(ws *websocket.Conn, mu *sync.Mutex, ev WingsEvent) {
+	data, _ := json.Marshal(ev)
+	mu.Lock()
+	defer mu.Unlock()
+	_ = ws.WriteMessage(websocket.TextMessage, data)
+}
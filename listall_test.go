@@ -0,0 +1,152 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+)
+
+// pagedPanel serves /api/application/servers as totalPages pages of two
+// servers each.
+type pagedPanel struct {
+	totalPages int
+	failPage   int
+	// block makes every page but the first wait for the request to be
+	// cancelled.
+	block bool
+
+	inFlight  int32
+	maxFlight int32
+	mu        sync.Mutex
+	served    []int
+}
+
+func (p *pagedPanel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	n := atomic.AddInt32(&p.inFlight, 1)
+	defer atomic.AddInt32(&p.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&p.maxFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&p.maxFlight, max, n) {
+			break
+		}
+	}
+
+	p.mu.Lock()
+	p.served = append(p.served, page)
+	p.mu.Unlock()
+
+	if page == p.failPage {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"ServerException","status":"500","detail":"boom"}]}`))
+		return
+	}
+	if p.block && page > 1 {
+		<-r.Context().Done()
+		return
+	}
+	// Later pages answer faster so that completion order differs from page
+	// order.
+	time.Sleep(time.Duration(p.totalPages-page) * time.Millisecond)
+
+	items := make([]string, 2)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"object":"server","attributes":{"id":%d}}`, (page-1)*2+i+1)
+	}
+	fmt.Fprintf(w, `{"object":"list","data":[%s],"meta":{"pagination":{"total":%d,"count":2,"per_page":2,"current_page":%d,"total_pages":%d}}}`,
+		strings.Join(items, ","), p.totalPages*2, page, p.totalPages)
+}
+
+func TestListAll_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	panel := &pagedPanel{totalPages: 12}
+	c := newTestClient(t, panel, pterodactyl.WithListConcurrency(3))
+
+	servers, err := c.ApplicationAPI.Servers.ListAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 24 {
+		t.Fatalf("expected 24 servers, got %d", len(servers))
+	}
+	for i, s := range servers {
+		if s.ID != i+1 {
+			t.Fatalf("expected server %d at index %d, got %d", i+1, i, s.ID)
+		}
+	}
+	if max := atomic.LoadInt32(&panel.maxFlight); max < 2 || max > 3 {
+		t.Errorf("expected between 2 and 3 requests in flight, got %d", max)
+	}
+}
+
+func TestListAll_Sequential(t *testing.T) {
+	t.Parallel()
+
+	panel := &pagedPanel{totalPages: 4}
+	c := newTestClient(t, panel, pterodactyl.WithListConcurrency(1))
+
+	servers, err := c.ApplicationAPI.Servers.ListAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 8 {
+		t.Errorf("expected 8 servers, got %d", len(servers))
+	}
+	if max := atomic.LoadInt32(&panel.maxFlight); max != 1 {
+		t.Errorf("expected sequential requests, got %d in flight", max)
+	}
+}
+
+func TestListAll_FailFast(t *testing.T) {
+	t.Parallel()
+
+	panel := &pagedPanel{totalPages: 50, failPage: 2, block: true}
+	c := newTestClient(t, panel, pterodactyl.WithListConcurrency(4))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ApplicationAPI.Servers.ListAll(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("expected the page error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ListAll did not return after a page failed")
+	}
+
+	panel.mu.Lock()
+	served := len(panel.served)
+	panel.mu.Unlock()
+	if served >= panel.totalPages {
+		t.Errorf("expected remaining pages to be skipped, %d were requested", served)
+	}
+}
+
+func TestClient_ListConcurrency(t *testing.T) {
+	t.Parallel()
+
+	c, _ := pterodactyl.NewClient("https://panel.example.com", "ptla_test", pterodactyl.ApplicationKey)
+	if n := c.ListConcurrency(); n != 1 {
+		t.Errorf("expected pages to be fetched one after another by default, got %d", n)
+	}
+
+	c, _ = pterodactyl.NewClient("https://panel.example.com", "ptla_test", pterodactyl.ApplicationKey,
+		pterodactyl.WithListConcurrency(8),
+		pterodactyl.WithRateLimiter(pterodactyl.NewRateLimiter(240, 2)))
+	if n := c.ListConcurrency(); n != 2 {
+		t.Errorf("expected concurrency to be capped by the limiter burst, got %d", n)
+	}
+}
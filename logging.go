@@ -0,0 +1,168 @@
+package pterodactyl
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// LogRecord describes one finished API call. Every field has already been
+// passed through the configured Redactor.
+type LogRecord struct {
+	// Operation is the SDK operation, see Call.Operation.
+	Operation string
+	Method    string
+	// URL is the full request URL with sensitive query parameters redacted.
+	URL string
+	// Status is the HTTP status code, or 0 when no response was received.
+	Status   int
+	Latency  time.Duration
+	Attempts int
+	// RequestHeader holds the request headers with credentials redacted.
+	RequestHeader http.Header
+	// RequestBody and ResponseBody are only set when LogOptions.Bodies is
+	// enabled.
+	RequestBody  []byte
+	ResponseBody []byte
+	Err          error
+}
+
+// Logger receives a LogRecord for every API call. Use NewSlogLogger (Go
+// 1.21+) to log through log/slog, or LoggerFunc to adapt any other logger.
+type Logger interface {
+	LogCall(ctx context.Context, rec *LogRecord)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(ctx context.Context, rec *LogRecord)
+
+// LogCall calls f(ctx, rec).
+func (f LoggerFunc) LogCall(ctx context.Context, rec *LogRecord) { f(ctx, rec) }
+
+// LogOptions configures WithLogger.
+type LogOptions struct {
+	// Bodies enables logging of request and response bodies.
+	Bodies bool
+	// MaxBodySize caps each logged body. Larger bodies are replaced by a
+	// placeholder since they cannot be redacted reliably. Defaults to 64 KiB.
+	MaxBodySize int
+	// Redactor strips secrets. Defaults to NewRedactor().
+	Redactor *Redactor
+}
+
+// WithLogger logs every API call to l. Like any middleware, its position in
+// the chain follows the order in which options are passed to NewClient.
+//
+//	sdk, _ := pterodactyl.NewClient(baseURL, token, key,
+//	    pterodactyl.WithLogger(pterodactyl.NewSlogLogger(slog.Default()),
+//	        pterodactyl.LogOptions{Bodies: true}))
+func WithLogger(l Logger, opts LogOptions) Option {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = 64 << 10
+	}
+	if opts.Redactor == nil {
+		opts.Redactor = NewRedactor()
+	}
+	return WithMiddleware(loggingMiddleware(l, opts))
+}
+
+func loggingMiddleware(l Logger, opts LogOptions) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*http.Response, error) {
+			rec := &LogRecord{
+				Operation: call.Operation,
+				Method:    call.Method,
+			}
+			if call.Request != nil {
+				rec.URL = opts.Redactor.URL(call.Request.URL)
+				rec.RequestHeader = opts.Redactor.Header(call.Request.Header)
+				if opts.Bodies {
+					rec.RequestBody = requestBody(call.Request, opts)
+				}
+			}
+			if opts.Bodies {
+				call.capture = &bodyCapture{limit: opts.MaxBodySize}
+			}
+
+			start := time.Now()
+			res, err := next(ctx, call)
+			rec.Latency = time.Since(start)
+			rec.Attempts = call.Attempts
+			rec.Err = err
+
+			var apiErr *errors.APIError
+			switch {
+			case stderrors.As(err, &apiErr):
+				rec.Status = apiErr.HTTPStatusCode
+			case res != nil:
+				rec.Status = res.StatusCode
+			}
+			if call.capture != nil {
+				rec.ResponseBody = call.capture.redacted(opts.Redactor)
+			}
+
+			l.LogCall(ctx, rec)
+			return res, err
+		}
+	}
+}
+
+func requestBody(req *http.Request, opts LogOptions) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	c := &bodyCapture{limit: opts.MaxBodySize}
+	_, _ = io.Copy(io.Discard, c.wrap(body))
+	return c.redacted(opts.Redactor)
+}
+
+// bodyCapture keeps a copy of the first limit bytes read through it.
+type bodyCapture struct {
+	limit    int
+	buf      bytes.Buffer
+	overflow int64
+}
+
+func (c *bodyCapture) wrap(rc io.ReadCloser) io.ReadCloser {
+	return &captureReader{ReadCloser: rc, c: c}
+}
+
+func (c *bodyCapture) redacted(r *Redactor) []byte {
+	if c.overflow > 0 {
+		return []byte(fmt.Sprintf("[body omitted: %d bytes]", c.buf.Len()+int(c.overflow)))
+	}
+	if c.buf.Len() == 0 {
+		return nil
+	}
+	return r.JSON(c.buf.Bytes())
+}
+
+type captureReader struct {
+	io.ReadCloser
+	c *bodyCapture
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if room := r.c.limit - r.c.buf.Len(); room > 0 {
+		if room > n {
+			room = n
+		}
+		r.c.buf.Write(p[:room])
+		r.c.overflow += int64(n - room)
+	} else {
+		r.c.overflow += int64(n)
+	}
+	return n, err
+}
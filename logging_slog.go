@@ -0,0 +1,50 @@
+//go:build go1.21
+
+package pterodactyl
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger returns a Logger that writes one slog record per call:
+// Info for successful calls, Warn for 4xx responses and Error for everything
+// else.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return LoggerFunc(func(ctx context.Context, rec *LogRecord) {
+		level := slog.LevelInfo
+		switch {
+		case rec.Err == nil:
+		case rec.Status >= 400 && rec.Status < 500:
+			level = slog.LevelWarn
+		default:
+			level = slog.LevelError
+		}
+		if !l.Enabled(ctx, level) {
+			return
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", rec.Method),
+			slog.String("url", rec.URL),
+			slog.Int("status", rec.Status),
+			slog.Duration("latency", rec.Latency),
+		}
+		if rec.Operation != "" {
+			attrs = append(attrs, slog.String("operation", rec.Operation))
+		}
+		if rec.Attempts > 1 {
+			attrs = append(attrs, slog.Int("attempts", rec.Attempts))
+		}
+		if rec.RequestBody != nil {
+			attrs = append(attrs, slog.String("request_body", string(rec.RequestBody)))
+		}
+		if rec.ResponseBody != nil {
+			attrs = append(attrs, slog.String("response_body", string(rec.ResponseBody)))
+		}
+		if rec.Err != nil {
+			attrs = append(attrs, slog.String("error", rec.Err.Error()))
+		}
+		l.LogAttrs(ctx, level, "pterodactyl request", attrs...)
+	})
+}
@@ -0,0 +1,55 @@
+//go:build go1.21
+
+package pterodactyl_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	panel := &scriptedPanel{statuses: []int{http.StatusOK, http.StatusNotFound}}
+	c := newTestClient(t, panel, pterodactyl.WithLogger(pterodactyl.NewSlogLogger(logger), pterodactyl.LogOptions{Bodies: true}))
+
+	_ = createUser(c)
+	_ = getUser(c)
+
+	if strings.Contains(buf.String(), "ptla_test") {
+		t.Fatalf("expected the API key never to be logged:\n%s", buf.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d", len(lines))
+	}
+	var first, second map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first["level"] != "INFO" || first["method"] != "POST" || first["status"] != float64(200) {
+		t.Errorf("unexpected first record %v", first)
+	}
+	if first["operation"] != "appapi.Users.Create" {
+		t.Errorf("unexpected operation %v", first["operation"])
+	}
+	if _, ok := first["request_body"]; !ok {
+		t.Error("expected the request body to be logged")
+	}
+	if second["level"] != "WARN" || second["status"] != float64(404) || second["error"] == nil {
+		t.Errorf("unexpected second record %v", second)
+	}
+}
@@ -0,0 +1,100 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []pterodactyl.LogRecord
+}
+
+func (l *recordingLogger) LogCall(_ context.Context, rec *pterodactyl.LogRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, *rec)
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	panel := &scriptedPanel{statuses: []int{http.StatusOK, http.StatusNotFound}}
+	c := newTestClient(t, panel, pterodactyl.WithLogger(logger, pterodactyl.LogOptions{}))
+
+	_ = getUser(c)
+	_ = getUser(c)
+
+	if len(logger.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(logger.records))
+	}
+	rec := logger.records[0]
+	if rec.Method != http.MethodGet || rec.Operation != "appapi.Users.Get" || rec.Status != http.StatusOK {
+		t.Errorf("unexpected record %+v", rec)
+	}
+	if !strings.HasSuffix(rec.URL, "/api/application/users/1") {
+		t.Errorf("unexpected URL %q", rec.URL)
+	}
+	if rec.Latency <= 0 || rec.Attempts != 1 {
+		t.Errorf("expected latency and attempts to be recorded, got %s and %d", rec.Latency, rec.Attempts)
+	}
+	if auth := rec.RequestHeader.Get("Authorization"); auth != pterodactyl.Redacted {
+		t.Errorf("expected the API key to be redacted, got %q", auth)
+	}
+	if rec.RequestBody != nil || rec.ResponseBody != nil {
+		t.Error("expected bodies to be omitted by default")
+	}
+
+	if rec := logger.records[1]; rec.Status != http.StatusNotFound || rec.Err == nil {
+		t.Errorf("expected the failed call to be logged with its status, got %+v", rec)
+	}
+}
+
+func TestWithLogger_Bodies(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"object":"server_database","attributes":{"id":"bEY4yAD5","name":"s1_db"},"relationships":{"password":{"object":"database_password","attributes":{"password":"hunter2"}}}}`))
+	}), pterodactyl.WithLogger(logger, pterodactyl.LogOptions{Bodies: true}))
+
+	ctx := context.Background()
+	db, err := c.ClientAPI.Servers("1a2b3c4d").Databases().Create(ctx, api.ClientDatabaseCreateOptions{DatabaseName: "db", Remote: "%"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if db.Password != "hunter2" {
+		t.Errorf("expected logging not to interfere with decoding, got password %q", db.Password)
+	}
+
+	rec := logger.records[0]
+	if !strings.Contains(string(rec.RequestBody), `"database":"db"`) {
+		t.Errorf("expected the request body to be logged, got %s", rec.RequestBody)
+	}
+	if !strings.Contains(string(rec.ResponseBody), "s1_db") {
+		t.Errorf("expected the response body to be logged, got %s", rec.ResponseBody)
+	}
+	if strings.Contains(string(rec.ResponseBody), "hunter2") {
+		t.Errorf("expected the password to be redacted, got %s", rec.ResponseBody)
+	}
+}
+
+func TestWithLogger_LargeBody(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	c := newTestClient(t, &scriptedPanel{}, pterodactyl.WithLogger(logger, pterodactyl.LogOptions{Bodies: true, MaxBodySize: 16}))
+	if err := getUser(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body := string(logger.records[0].ResponseBody); !strings.HasPrefix(body, "[body omitted") {
+		t.Errorf("expected an oversized body to be omitted, got %q", body)
+	}
+}
@@ -0,0 +1,93 @@
+package pterodactyl
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// Call describes one SDK call as it passes through the middleware chain.
+type Call struct {
+	// Method is the HTTP method, e.g. "GET".
+	Method string
+	// Endpoint is the endpoint the service asked for, relative to the panel
+	// URL, e.g. "/api/application/users/1".
+	Endpoint string
+	// Operation names the SDK method that issued the call, e.g.
+	// "appapi.Servers.Create". It is empty for endpoints the SDK does not
+	// know.
+	Operation string
+	// Options are the pagination options passed to NewRequest, if any.
+	Options *api.PaginationOptions
+	// Request is the prepared HTTP request. Middleware may replace it (for
+	// example with a clone carrying a rotated Authorization header) before
+	// calling next.
+	Request *http.Request
+	// Attempts is the number of times the request was sent, including
+	// retries. It is set once the innermost Handler returns.
+	Attempts int
+	// Streaming is set for calls made through Stream: the body of a
+	// successful response is returned open, to be read and closed by the
+	// caller after the chain has returned.
+	Streaming bool
+
+	v        any          // decode target passed to Do
+	capture  *bodyCapture // set by the logging middleware
+	external bool         // the request goes to Wings rather than the panel
+}
+
+// Handler performs a Call. The innermost Handler sends the request (with
+// rate limiting and retries), maps error statuses to *errors.APIError and
+// decodes the response body; the returned response's body is already
+// closed unless the call is Streaming.
+type Handler func(ctx context.Context, call *Call) (*http.Response, error)
+
+// Middleware wraps a Handler with cross-cutting behaviour such as logging,
+// tracing, auditing or credential rotation.
+//
+//	logging := func(next pterodactyl.Handler) pterodactyl.Handler {
+//	    return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+//	        res, err := next(ctx, call)
+//	        log.Printf("%s %s: %v", call.Method, call.Endpoint, err)
+//	        return res, err
+//	    }
+//	}
+//	sdk, _ := pterodactyl.NewClient(baseURL, token, key, pterodactyl.WithMiddleware(logging))
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends mw to the client's middleware chain. The first
+// middleware registered is the outermost one: it sees the call first and the
+// result last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) { c.middleware = append(c.middleware, mw...) }
+}
+
+type callKey struct{}
+
+// withCall records the NewRequest arguments on the request's context so that
+// Do can hand them to the middleware chain.
+func withCall(req *http.Request, call *Call) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), callKey{}, call))
+}
+
+// callFromRequest returns the Call recorded by NewRequest, or one derived
+// from req itself for requests built elsewhere.
+func callFromRequest(req *http.Request) *Call {
+	if call, ok := req.Context().Value(callKey{}).(*Call); ok {
+		return &Call{Method: call.Method, Endpoint: call.Endpoint, Operation: call.Operation, Options: call.Options, external: call.external}
+	}
+	endpoint := req.URL.RequestURI()
+	return &Call{Method: req.Method, Endpoint: endpoint, Operation: operationName(req.Method, endpoint)}
+}
+
+// chain builds the handler used by Do from the registered middleware.
+func (c *Client) chain() Handler {
+	h := Handler(func(ctx context.Context, call *Call) (*http.Response, error) {
+		return c.do(ctx, call)
+	})
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
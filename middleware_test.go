@@ -0,0 +1,190 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/errors"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+		calls []pterodactyl.Call
+		errs  []error
+	)
+	record := func(name string) pterodactyl.Middleware {
+		return func(next pterodactyl.Handler) pterodactyl.Handler {
+			return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name+" before")
+				mu.Unlock()
+				res, err := next(ctx, call)
+				mu.Lock()
+				order = append(order, name+" after")
+				if name == "outer" {
+					calls = append(calls, *call)
+					errs = append(errs, err)
+				}
+				mu.Unlock()
+				return res, err
+			}
+		}
+	}
+
+	panel := &scriptedPanel{statuses: []int{http.StatusOK, http.StatusNotFound}}
+	c := newTestClient(t, panel, pterodactyl.WithMiddleware(record("outer"), record("inner")))
+
+	if err := getUser(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := getUser(c); !errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	expectedOrder := "outer before,inner before,inner after,outer after"
+	if got := strings.Join(order[:4], ","); got != expectedOrder {
+		t.Errorf("expected order %q, got %q", expectedOrder, got)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(calls))
+	}
+	if calls[0].Method != http.MethodGet || calls[0].Endpoint != "/api/application/users/1" {
+		t.Errorf("unexpected call %s %s", calls[0].Method, calls[0].Endpoint)
+	}
+	if errs[0] != nil {
+		t.Errorf("expected first call to succeed, got %v", errs[0])
+	}
+	if !errors.IsNotFound(errs[1]) {
+		t.Errorf("expected middleware to see the API error, got %v", errs[1])
+	}
+}
+
+func TestWithMiddleware_PaginationOptions(t *testing.T) {
+	t.Parallel()
+
+	var seen *api.PaginationOptions
+	mw := func(next pterodactyl.Handler) pterodactyl.Handler {
+		return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+			seen = call.Options
+			return next(ctx, call)
+		}
+	}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"object":"list","data":[],"meta":{"pagination":{"total":0,"count":0,"per_page":5,"current_page":2,"total_pages":0}}}`))
+	}), pterodactyl.WithMiddleware(mw))
+
+	_, _, err := c.ApplicationAPI.Users.List(context.Background(), &api.PaginationOptions{Page: 2, PerPage: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen == nil || seen.Page != 2 || seen.PerPage != 5 {
+		t.Errorf("expected middleware to see the pagination options, got %+v", seen)
+	}
+}
+
+func TestWithMiddleware_RewritesRequest(t *testing.T) {
+	t.Parallel()
+
+	var auth string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"object":"user","attributes":{"id":1}}`))
+	}), pterodactyl.WithMiddleware(func(next pterodactyl.Handler) pterodactyl.Handler {
+		return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+			call.Request = call.Request.Clone(ctx)
+			call.Request.Header.Set("Authorization", "Bearer ptla_rotated")
+			return next(ctx, call)
+		}
+	}))
+
+	if err := getUser(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if auth != "Bearer ptla_rotated" {
+		t.Errorf("expected the rotated key to be sent, got %q", auth)
+	}
+}
+
+func TestWithMiddleware_ShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	panel := &scriptedPanel{}
+	denied := &errors.APIError{HTTPStatusCode: http.StatusForbidden}
+	c := newTestClient(t, panel, pterodactyl.WithMiddleware(func(next pterodactyl.Handler) pterodactyl.Handler {
+		return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+			if call.Method != http.MethodGet {
+				return nil, denied
+			}
+			return next(ctx, call)
+		}
+	}))
+
+	if err := createUser(c); !errors.IsUnauthorized(err) {
+		t.Errorf("expected the middleware's error, got %v", err)
+	}
+	if hits := panel.hits(); hits != 0 {
+		t.Errorf("expected no request to reach the panel, got %d", hits)
+	}
+}
+
+func TestCall_OperationAndAttempts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls []pterodactyl.Call
+	)
+	capture := func(next pterodactyl.Handler) pterodactyl.Handler {
+		return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+			res, err := next(ctx, call)
+			mu.Lock()
+			calls = append(calls, *call)
+			mu.Unlock()
+			return res, err
+		}
+	}
+	panel := &scriptedPanel{statuses: []int{http.StatusTooManyRequests}}
+	c := newTestClient(t, panel, fastRetry(), pterodactyl.WithMiddleware(capture))
+	ctx := context.Background()
+
+	_ = createUser(c)
+	_, _ = c.ApplicationAPI.Users.GetExternalID(ctx, "ext-1")
+	_, _ = c.ApplicationAPI.Servers.Databases(ctx, 3).Get(ctx, 7)
+	_ = c.ApplicationAPI.Nodes.Allocations(ctx, 2).Delete(ctx, 9)
+	_, _ = c.ClientAPI.Servers("1a2b3c4d").Files().List(ctx, "/")
+	_ = c.ClientAPI.Servers("1a2b3c4d").Schedules().DeleteTask(ctx, 1, 2)
+	_, _ = c.ClientAPI.Account().GetDetails(ctx)
+
+	expected := []string{
+		"appapi.Users.Create",
+		"appapi.Users.GetExternalID",
+		"appapi.Servers.Databases.Get",
+		"appapi.Nodes.Allocations.Delete",
+		"clientapi.Servers.Files.List",
+		"clientapi.Servers.Schedules.DeleteTask",
+		"clientapi.Account.GetDetails",
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected %d calls, got %d", len(expected), len(calls))
+	}
+	for i, op := range expected {
+		if calls[i].Operation != op {
+			t.Errorf("call %d: expected operation %q, got %q", i, op, calls[i].Operation)
+		}
+	}
+	if calls[0].Attempts != 2 {
+		t.Errorf("expected the retried call to report 2 attempts, got %d", calls[0].Attempts)
+	}
+	if calls[1].Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls[1].Attempts)
+	}
+}
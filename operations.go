@@ -0,0 +1,180 @@
+package pterodactyl
+
+import (
+	"strings"
+)
+
+// routes maps "METHOD /path/template" to the logical SDK operation that
+// issues it. "{}" matches exactly one path segment. Within a method, earlier
+// entries win, so literal segments are listed before wildcards. A service
+// method whose requests are missing here fails
+// TestOperations_EveryServiceMethod.
+var routes = []struct{ route, operation string }{
+	// ----- Application API ----------------------------------------------------
+	{"GET /api/application/users", "appapi.Users.List"},
+	{"GET /api/application/users/external/{}", "appapi.Users.GetExternalID"},
+	{"GET /api/application/users/{}", "appapi.Users.Get"},
+	{"POST /api/application/users", "appapi.Users.Create"},
+	{"PATCH /api/application/users/{}", "appapi.Users.Update"},
+	{"DELETE /api/application/users/{}", "appapi.Users.Delete"},
+
+	{"GET /api/application/nodes", "appapi.Nodes.List"},
+	{"GET /api/application/nodes/{}", "appapi.Nodes.Get"},
+	{"GET /api/application/nodes/{}/configuration", "appapi.Nodes.GetConfiguration"},
+	{"POST /api/application/nodes", "appapi.Nodes.Create"},
+	{"PATCH /api/application/nodes/{}", "appapi.Nodes.Update"},
+	{"DELETE /api/application/nodes/{}", "appapi.Nodes.Delete"},
+	{"GET /api/application/nodes/{}/allocations", "appapi.Nodes.Allocations.List"},
+	{"POST /api/application/nodes/{}/allocations", "appapi.Nodes.Allocations.Create"},
+	{"DELETE /api/application/nodes/{}/allocations/{}", "appapi.Nodes.Allocations.Delete"},
+
+	{"GET /api/application/locations", "appapi.Locations.List"},
+	{"GET /api/application/locations/{}", "appapi.Locations.Get"},
+	{"POST /api/application/locations", "appapi.Locations.Create"},
+	{"PATCH /api/application/locations/{}", "appapi.Locations.Update"},
+	{"DELETE /api/application/locations/{}", "appapi.Locations.Delete"},
+
+	{"GET /api/application/servers", "appapi.Servers.List"},
+	{"GET /api/application/servers/external/{}", "appapi.Servers.GetExternal"},
+	{"GET /api/application/servers/{}", "appapi.Servers.Get"},
+	{"POST /api/application/servers", "appapi.Servers.Create"},
+	{"PATCH /api/application/servers/{}/details", "appapi.Servers.UpdateDetails"},
+	{"PATCH /api/application/servers/{}/build", "appapi.Servers.UpdateBuild"},
+	{"PATCH /api/application/servers/{}/startup", "appapi.Servers.UpdateStartup"},
+	{"POST /api/application/servers/{}/suspend", "appapi.Servers.Suspend"},
+	{"POST /api/application/servers/{}/unsuspend", "appapi.Servers.Unsuspend"},
+	{"POST /api/application/servers/{}/reinstall", "appapi.Servers.Reinstall"},
+	{"DELETE /api/application/servers/{}", "appapi.Servers.Delete"},
+	{"GET /api/application/servers/{}/databases", "appapi.Servers.Databases.List"},
+	{"GET /api/application/servers/{}/databases/{}", "appapi.Servers.Databases.Get"},
+	{"POST /api/application/servers/{}/databases", "appapi.Servers.Databases.Create"},
+	{"POST /api/application/servers/{}/databases/{}/reset-password", "appapi.Servers.Databases.ResetPassword"},
+	{"DELETE /api/application/servers/{}/databases/{}", "appapi.Servers.Databases.Delete"},
+
+	{"GET /api/application/nests", "appapi.Nests.List"},
+	{"GET /api/application/nests/{}", "appapi.Nests.Get"},
+	{"GET /api/application/nests/{}/eggs", "appapi.Nests.Eggs.List"},
+	{"GET /api/application/nests/{}/eggs/{}", "appapi.Nests.Eggs.Get"},
+
+	// ----- Client API ---------------------------------------------------------
+	{"GET /api/client", "clientapi.ListServers"},
+	{"GET /api/client/permissions", "clientapi.ListPermissions"},
+
+	{"GET /api/client/account", "clientapi.Account.GetDetails"},
+	{"GET /api/client/account/two-factor", "clientapi.Account.GetTwoFactorDetails"},
+	{"POST /api/client/account/two-factor", "clientapi.Account.EnableTwoFactor"},
+	{"DELETE /api/client/account/two-factor", "clientapi.Account.DisableTwoFactor"},
+	{"PUT /api/client/account/email", "clientapi.Account.UpdateEmail"},
+	{"PUT /api/client/account/password", "clientapi.Account.UpdatePassword"},
+	{"GET /api/client/account/api-keys", "clientapi.Account.APIKeys.List"},
+	{"POST /api/client/account/api-keys", "clientapi.Account.APIKeys.Create"},
+	{"DELETE /api/client/account/api-keys/{}", "clientapi.Account.APIKeys.Delete"},
+
+	{"GET /api/client/servers/{}", "clientapi.Servers.GetDetails"},
+	{"GET /api/client/servers/{}/websocket", "clientapi.Servers.GetWebsocket"},
+	{"GET /api/client/servers/{}/resources", "clientapi.Servers.GetResources"},
+	{"POST /api/client/servers/{}/command", "clientapi.Servers.SendCommand"},
+	{"POST /api/client/servers/{}/power", "clientapi.Servers.SetPowerState"},
+
+	{"GET /api/client/servers/{}/databases", "clientapi.Servers.Databases.List"},
+	{"POST /api/client/servers/{}/databases", "clientapi.Servers.Databases.Create"},
+	{"POST /api/client/servers/{}/databases/{}/rotate-password", "clientapi.Servers.Databases.RotatePassword"},
+	{"DELETE /api/client/servers/{}/databases/{}", "clientapi.Servers.Databases.Delete"},
+
+	{"GET /api/client/servers/{}/files/list", "clientapi.Servers.Files.List"},
+	{"GET /api/client/servers/{}/files/contents", "clientapi.Servers.Files.GetContents"},
+	{"GET /api/client/servers/{}/files/download", "clientapi.Servers.Files.Download"},
+	{"PUT /api/client/servers/{}/files/rename", "clientapi.Servers.Files.Rename"},
+	{"POST /api/client/servers/{}/files/copy", "clientapi.Servers.Files.Copy"},
+	{"POST /api/client/servers/{}/files/write", "clientapi.Servers.Files.Write"},
+	{"POST /api/client/servers/{}/files/compress", "clientapi.Servers.Files.Compress"},
+	{"POST /api/client/servers/{}/files/decompress", "clientapi.Servers.Files.Decompress"},
+	{"POST /api/client/servers/{}/files/delete", "clientapi.Servers.Files.Delete"},
+	{"POST /api/client/servers/{}/files/create-folder", "clientapi.Servers.Files.CreateFolder"},
+	{"GET /api/client/servers/{}/files/upload", "clientapi.Servers.Files.GetUploadURL"},
+
+	{"GET /api/client/servers/{}/schedules", "clientapi.Servers.Schedules.List"},
+	{"POST /api/client/servers/{}/schedules", "clientapi.Servers.Schedules.Create"},
+	{"GET /api/client/servers/{}/schedules/{}", "clientapi.Servers.Schedules.Details"},
+	{"POST /api/client/servers/{}/schedules/{}", "clientapi.Servers.Schedules.Update"},
+	{"DELETE /api/client/servers/{}/schedules/{}", "clientapi.Servers.Schedules.Delete"},
+	{"POST /api/client/servers/{}/schedules/{}/tasks", "clientapi.Servers.Schedules.CreateTask"},
+	{"POST /api/client/servers/{}/schedules/{}/tasks/{}", "clientapi.Servers.Schedules.UpdateTask"},
+	{"DELETE /api/client/servers/{}/schedules/{}/tasks/{}", "clientapi.Servers.Schedules.DeleteTask"},
+
+	{"GET /api/client/servers/{}/network/allocations", "clientapi.Servers.Network.ListAllocations"},
+	{"POST /api/client/servers/{}/network/allocations", "clientapi.Servers.Network.AssignAllocation"},
+	{"POST /api/client/servers/{}/network/allocations/{}", "clientapi.Servers.Network.SetAllocationNote"},
+	{"POST /api/client/servers/{}/network/allocations/{}/primary", "clientapi.Servers.Network.SetPrimaryAllocation"},
+	{"DELETE /api/client/servers/{}/network/allocations/{}", "clientapi.Servers.Network.UnassignAllocation"},
+
+	{"GET /api/client/servers/{}/users", "clientapi.Servers.Users.List"},
+	{"POST /api/client/servers/{}/users", "clientapi.Servers.Users.Create"},
+	{"GET /api/client/servers/{}/users/{}", "clientapi.Servers.Users.Details"},
+	{"POST /api/client/servers/{}/users/{}", "clientapi.Servers.Users.Update"},
+	{"DELETE /api/client/servers/{}/users/{}", "clientapi.Servers.Users.Delete"},
+
+	{"GET /api/client/servers/{}/backups", "clientapi.Servers.Backups.List"},
+	{"POST /api/client/servers/{}/backups", "clientapi.Servers.Backups.Create"},
+	{"GET /api/client/servers/{}/backups/{}", "clientapi.Servers.Backups.Details"},
+	{"GET /api/client/servers/{}/backups/{}/download", "clientapi.Servers.Backups.Download"},
+	{"DELETE /api/client/servers/{}/backups/{}", "clientapi.Servers.Backups.Delete"},
+
+	{"GET /api/client/servers/{}/startup", "clientapi.Servers.Startup.ListVariables"},
+	{"PUT /api/client/servers/{}/startup/variable", "clientapi.Servers.Startup.UpdateVariable"},
+
+	{"POST /api/client/servers/{}/settings/rename", "clientapi.Servers.Settings.Rename"},
+	{"POST /api/client/servers/{}/settings/reinstall", "clientapi.Servers.Settings.Reinstall"},
+
+	// ----- Wings (signed URLs) ------------------------------------------------
+	{"GET /download/file", "wings.DownloadFile"},
+	{"GET /download/backup", "wings.DownloadBackup"},
+	{"POST /upload/file", "wings.UploadFile"},
+}
+
+type route struct {
+	segments  []string
+	operation string
+}
+
+// routeTable indexes routes by method.
+var routeTable = func() map[string][]route {
+	table := make(map[string][]route)
+	for _, r := range routes {
+		method, path, _ := strings.Cut(r.route, " ")
+		table[method] = append(table[method], route{segments: strings.Split(path, "/"), operation: r.operation})
+	}
+	return table
+}()
+
+// operationName returns the SDK operation for method and endpoint, e.g.
+// "appapi.Servers.Create", or "" when the endpoint is not a known route.
+func operationName(method, endpoint string) string {
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	// Signed Wings URLs are absolute.
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		endpoint = endpoint[i+3:]
+		if j := strings.IndexByte(endpoint, '/'); j >= 0 {
+			endpoint = endpoint[j:]
+		} else {
+			endpoint = ""
+		}
+	}
+	segments := strings.Split(strings.TrimSuffix(endpoint, "/"), "/")
+
+next:
+	for _, r := range routeTable[method] {
+		if len(r.segments) != len(segments) {
+			continue
+		}
+		for i, s := range r.segments {
+			if s != "{}" && s != segments[i] {
+				continue next
+			}
+		}
+		return r.operation
+	}
+	return ""
+}
@@ -0,0 +1,188 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// operationsPanel answers every request with a body that decodes, at least
+// partly, into any response type, and signs download and upload URLs that
+// point back at itself.
+type operationsPanel struct{}
+
+func (operationsPanel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wings := ""
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/download/") || r.URL.Path == "/upload/file":
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = io.WriteString(w, "data")
+		return
+	case strings.HasSuffix(r.URL.Path, "/files/download"):
+		wings = "/download/file?token=t"
+	case strings.HasSuffix(r.URL.Path, "/download"):
+		wings = "/download/backup?token=t"
+	case strings.HasSuffix(r.URL.Path, "/files/upload"):
+		wings = "/upload/file?token=t"
+	}
+	_, _ = io.WriteString(w, `{"object":"list","data":[],"attributes":{"url":"http://`+r.Host+wings+`"},`+
+		`"meta":{"pagination":{"total":0,"count":0,"per_page":100,"current_page":1,"total_pages":1}}}`)
+}
+
+// TestOperations_EveryServiceMethod calls every method reachable from the
+// application and client APIs and checks that each request it makes maps
+// to an operation, so that new endpoints are not left out of the routes
+// table.
+func TestOperations_EveryServiceMethod(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls []*pterodactyl.Call
+	)
+	record := func(next pterodactyl.Handler) pterodactyl.Handler {
+		return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+			mu.Lock()
+			calls = append(calls, call)
+			mu.Unlock()
+			return next(ctx, call)
+		}
+	}
+	c := newTestClient(t, operationsPanel{}, pterodactyl.WithMiddleware(record))
+
+	// Methods that only return other services or configure local state.
+	noRequests := map[string]bool{"ClearCache": true}
+	seen := make(map[reflect.Type]bool)
+
+	var visit func(name string, v reflect.Value)
+	visit = func(name string, v reflect.Value) {
+		if seen[v.Type()] {
+			return
+		}
+		seen[v.Type()] = true
+		if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+			for i := 0; i < v.Elem().NumField(); i++ {
+				if f := v.Elem().Field(i); f.CanInterface() && isService(f.Type()) && !f.IsNil() {
+					visit(name+"."+v.Elem().Type().Field(i).Name, f)
+				}
+			}
+		}
+		for i := 0; i < v.NumMethod(); i++ {
+			method := v.Type().Method(i).Name
+			full := name + "." + method
+
+			mu.Lock()
+			calls = nil
+			mu.Unlock()
+			results := callMethod(t, full, v.Method(i))
+
+			var services []reflect.Value
+			for _, r := range results {
+				switch {
+				case isService(r.Type()) && !r.IsNil():
+					services = append(services, r)
+				case r.MethodByName("Next").IsValid():
+					// Iterators fetch lazily.
+					r.MethodByName("Next").Call(nil)
+				}
+			}
+
+			mu.Lock()
+			made := calls
+			mu.Unlock()
+			if len(made) == 0 && len(services) == 0 && !noRequests[method] {
+				t.Errorf("%s: made no requests", full)
+			}
+			for _, call := range made {
+				if call.Operation == "" {
+					t.Errorf("%s: no operation for %s %s", full, call.Method, call.Endpoint)
+				}
+			}
+			for _, s := range services {
+				visit(full, s)
+			}
+		}
+	}
+	visit("appapi", reflect.ValueOf(c.ApplicationAPI))
+	visit("clientapi", reflect.ValueOf(c.ClientAPI))
+}
+
+// isService reports whether t is one of the SDK's service types.
+func isService(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath()
+	return (t.Kind() == reflect.Interface || t.Kind() == reflect.Struct) &&
+		(strings.HasSuffix(pkg, "/appapi") || strings.HasSuffix(pkg, "/clientapi"))
+}
+
+// callMethod calls m with placeholder arguments. A panic, for example from a
+// nil response field, fails nothing: only the requests made matter.
+func callMethod(t *testing.T, name string, m reflect.Value) (results []reflect.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Logf("%s: recovered %v", name, r)
+			results = nil
+		}
+	}()
+	mt := m.Type()
+	args := make([]reflect.Value, 0, mt.NumIn())
+	for i := 0; i < mt.NumIn(); i++ {
+		if mt.IsVariadic() && i == mt.NumIn()-1 {
+			break
+		}
+		args = append(args, placeholder(mt.In(i)))
+	}
+	return m.Call(args)
+}
+
+var (
+	contextType    = reflect.TypeOf((*context.Context)(nil)).Elem()
+	readerType     = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	writerType     = reflect.TypeOf((*io.Writer)(nil)).Elem()
+	regexpType     = reflect.TypeOf((*regexp.Regexp)(nil))
+	uploadFileType = reflect.TypeOf(api.UploadFile{})
+)
+
+func placeholder(t reflect.Type) reflect.Value {
+	switch t {
+	case contextType:
+		return reflect.ValueOf(context.Background())
+	case readerType:
+		return reflect.ValueOf(strings.NewReader("data"))
+	case writerType:
+		return reflect.ValueOf(io.Discard)
+	case regexpType:
+		return reflect.ValueOf(regexp.MustCompile("data"))
+	case uploadFileType:
+		return reflect.ValueOf(api.UploadFile{Name: "x", Content: strings.NewReader("data")})
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf("x").Convert(t)
+	case reflect.Int, reflect.Int64:
+		return reflect.ValueOf(1).Convert(t)
+	case reflect.Slice:
+		return reflect.Append(reflect.MakeSlice(t, 0, 1), placeholder(t.Elem()))
+	case reflect.Ptr:
+		return reflect.New(t.Elem())
+	case reflect.Func:
+		return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+			out := make([]reflect.Value, t.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(t.Out(i))
+			}
+			return out
+		})
+	}
+	return reflect.Zero(t)
+}
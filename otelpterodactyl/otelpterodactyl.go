@@ -0,0 +1,200 @@
+// Package otelpterodactyl instruments go-pterodactyl with OpenTelemetry.
+//
+// It lives in its own module so that the SDK itself does not depend on
+// OpenTelemetry. Install the middleware when building the client:
+//
+//	sdk, _ := pterodactyl.NewClient(baseURL, token, key,
+//	    pterodactyl.WithMiddleware(otelpterodactyl.Middleware()))
+//
+// Every API call then produces a client span named after the SDK operation
+// (e.g. "appapi.Servers.Create") and is recorded in the
+// pterodactyl.client.request.duration histogram and, on failure, the
+// pterodactyl.client.request.errors counter.
+package otelpterodactyl
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope used for the tracer and meter.
+const ScopeName = "github.com/davidarkless/go-pterodactyl/otelpterodactyl"
+
+// Attribute keys specific to the SDK.
+const (
+	OperationKey = attribute.Key("pterodactyl.operation")
+	AttemptsKey  = attribute.Key("pterodactyl.attempts")
+	PageKey      = attribute.Key("pterodactyl.page")
+	PerPageKey   = attribute.Key("pterodactyl.per_page")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider. Defaults to the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the MeterProvider. Defaults to the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// WithPropagators sets the propagators used to inject the span context into
+// outgoing requests. Defaults to the global TextMapPropagator.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) { c.propagators = p }
+}
+
+// Middleware returns a pterodactyl.Middleware that traces and measures every
+// call. Register it first so that its span covers the other middleware.
+func Middleware(opts ...Option) pterodactyl.Middleware {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, o := range opts {
+		o(cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	meter := cfg.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram("pterodactyl.client.request.duration",
+		metric.WithDescription("Duration of Pterodactyl API calls, including retries."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	failures, err := meter.Int64Counter("pterodactyl.client.request.errors",
+		metric.WithDescription("Number of Pterodactyl API calls that returned an error."),
+		metric.WithUnit("{error}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next pterodactyl.Handler) pterodactyl.Handler {
+		return func(ctx context.Context, call *pterodactyl.Call) (*http.Response, error) {
+			operation := operationName(call)
+			start := time.Now()
+
+			ctx, span := tracer.Start(ctx, operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(requestAttributes(call, operation)...))
+			defer span.End()
+
+			if call.Request != nil {
+				call.Request = call.Request.Clone(ctx)
+				cfg.propagators.Inject(ctx, propagation.HeaderCarrier(call.Request.Header))
+			}
+
+			res, err := next(ctx, call)
+
+			status := statusCode(res, err)
+			metricAttrs := []attribute.KeyValue{
+				OperationKey.String(operation),
+				semconv.HTTPRequestMethodKey.String(call.Method),
+			}
+			if status > 0 {
+				metricAttrs = append(metricAttrs, semconv.HTTPResponseStatusCode(status))
+				span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			}
+			if call.Attempts > 0 {
+				span.SetAttributes(AttemptsKey.Int(call.Attempts))
+			}
+			if call.Attempts > 1 {
+				span.SetAttributes(semconv.HTTPRequestResendCount(call.Attempts - 1))
+			}
+			if err != nil {
+				errType := errorType(err, status)
+				metricAttrs = append(metricAttrs, semconv.ErrorTypeKey.String(errType))
+				span.SetAttributes(semconv.ErrorTypeKey.String(errType))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			set := metric.WithAttributeSet(attribute.NewSet(metricAttrs...))
+			if duration != nil {
+				duration.Record(ctx, time.Since(start).Seconds(), set)
+			}
+			if err != nil && failures != nil {
+				failures.Add(ctx, 1, set)
+			}
+			return res, err
+		}
+	}
+}
+
+func operationName(call *pterodactyl.Call) string {
+	if call.Operation != "" {
+		return call.Operation
+	}
+	return "pterodactyl " + call.Method
+}
+
+func requestAttributes(call *pterodactyl.Call, operation string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(operation),
+		semconv.HTTPRequestMethodKey.String(call.Method),
+	}
+	if call.Request != nil {
+		attrs = append(attrs,
+			semconv.ServerAddress(call.Request.URL.Hostname()),
+			semconv.URLPath(call.Request.URL.Path))
+	}
+	if o := call.Options; o != nil {
+		if o.Page > 0 {
+			attrs = append(attrs, PageKey.Int(o.Page))
+		}
+		if o.PerPage > 0 {
+			attrs = append(attrs, PerPageKey.Int(o.PerPage))
+		}
+	}
+	return attrs
+}
+
+// statusCode returns the HTTP status of the call, or 0 when no response was
+// received.
+func statusCode(res *http.Response, err error) int {
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode
+	}
+	if res != nil {
+		return res.StatusCode
+	}
+	return 0
+}
+
+func errorType(err error, status int) string {
+	switch {
+	case status > 0:
+		return strconv.Itoa(status)
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case stderrors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "_OTHER"
+	}
+}
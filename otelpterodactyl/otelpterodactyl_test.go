@@ -0,0 +1,223 @@
+package otelpterodactyl_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+	"github.com/davidarkless/go-pterodactyl/otelpterodactyl"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type harness struct {
+	client   *pterodactyl.Client
+	spans    *tracetest.SpanRecorder
+	metrics  *sdkmetric.ManualReader
+	tracer   trace.Tracer
+	mu       sync.Mutex
+	headers  []http.Header
+	statuses []int
+}
+
+func newHarness(t *testing.T, statuses ...int) *harness {
+	t.Helper()
+	h := &harness{
+		spans:    tracetest.NewSpanRecorder(),
+		metrics:  sdkmetric.NewManualReader(),
+		statuses: statuses,
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(h.spans))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(h.metrics))
+	h.tracer = tp.Tracer("test")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		h.headers = append(h.headers, r.Header.Clone())
+		status := http.StatusOK
+		if len(h.statuses) > 0 {
+			status, h.statuses = h.statuses[0], h.statuses[1:]
+		}
+		h.mu.Unlock()
+
+		w.WriteHeader(status)
+		switch {
+		case status >= 400:
+			_, _ = w.Write([]byte(`{"errors":[{"code":"NotFoundHttpException","status":"404","detail":"not found"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/application/users":
+			_, _ = w.Write([]byte(`{"object":"list","data":[],"meta":{"pagination":{"total":0,"count":0,"per_page":10,"current_page":3,"total_pages":0}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"object":"server","attributes":{"id":1}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := pterodactyl.NewClient(srv.URL, "ptla_test", pterodactyl.ApplicationKey,
+		pterodactyl.WithRetry(pterodactyl.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		pterodactyl.WithMiddleware(otelpterodactyl.Middleware(
+			otelpterodactyl.WithTracerProvider(tp),
+			otelpterodactyl.WithMeterProvider(mp),
+			otelpterodactyl.WithPropagators(propagation.TraceContext{}),
+		)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.client = client
+	return h
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMiddleware_Span(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	ctx, parent := h.tracer.Start(context.Background(), "parent")
+	_, err := h.client.ApplicationAPI.Servers.Create(ctx, api.ServerCreateOptions{Name: "test"})
+	parent.End()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := h.spans.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "appapi.Servers.Create" {
+		t.Errorf("expected span name appapi.Servers.Create, got %q", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("expected a client span, got %s", span.SpanKind())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected the span to be a child of the caller's span")
+	}
+	if v, ok := attr(span.Attributes(), "http.response.status_code"); !ok || v.AsInt64() != 200 {
+		t.Errorf("expected status code 200, got %v", v.Emit())
+	}
+	if v, ok := attr(span.Attributes(), otelpterodactyl.AttemptsKey); !ok || v.AsInt64() != 1 {
+		t.Errorf("expected 1 attempt, got %v", v.Emit())
+	}
+
+	// The trace context must reach the panel.
+	if tp := h.headers[0].Get("Traceparent"); tp == "" {
+		t.Error("expected a traceparent header on the outgoing request")
+	}
+}
+
+func TestMiddleware_RetriesAndErrors(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t, http.StatusServiceUnavailable, http.StatusNotFound)
+	_, err := h.client.ApplicationAPI.Users.Get(context.Background(), 7)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	spans := h.spans.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "appapi.Users.Get" {
+		t.Errorf("unexpected span name %q", span.Name())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", span.Status())
+	}
+	if v, _ := attr(span.Attributes(), "http.response.status_code"); v.AsInt64() != 404 {
+		t.Errorf("expected status code 404, got %v", v.Emit())
+	}
+	if v, _ := attr(span.Attributes(), "http.request.resend_count"); v.AsInt64() != 1 {
+		t.Errorf("expected 1 resend, got %v", v.Emit())
+	}
+	if v, _ := attr(span.Attributes(), otelpterodactyl.AttemptsKey); v.AsInt64() != 2 {
+		t.Errorf("expected 2 attempts, got %v", v.Emit())
+	}
+	if len(span.Events()) == 0 || span.Events()[0].Name != "exception" {
+		t.Error("expected the error to be recorded on the span")
+	}
+}
+
+func TestMiddleware_Pagination(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t)
+	if _, _, err := h.client.ApplicationAPI.Users.List(context.Background(), &api.PaginationOptions{Page: 3, PerPage: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	span := h.spans.Ended()[0]
+	if v, _ := attr(span.Attributes(), otelpterodactyl.PageKey); v.AsInt64() != 3 {
+		t.Errorf("expected page 3, got %v", v.Emit())
+	}
+	if v, _ := attr(span.Attributes(), otelpterodactyl.PerPageKey); v.AsInt64() != 10 {
+		t.Errorf("expected per_page 10, got %v", v.Emit())
+	}
+}
+
+func TestMiddleware_Metrics(t *testing.T) {
+	t.Parallel()
+
+	h := newHarness(t, http.StatusOK, http.StatusNotFound)
+	ctx := context.Background()
+	_, _ = h.client.ApplicationAPI.Users.Get(ctx, 1)
+	_, _ = h.client.ApplicationAPI.Users.Get(ctx, 2)
+
+	var rm metricdata.ResourceMetrics
+	if err := h.metrics.Collect(ctx, &rm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var durations, failures int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if m.Name != "pterodactyl.client.request.duration" {
+					continue
+				}
+				for _, dp := range data.DataPoints {
+					if op, _ := dp.Attributes.Value(otelpterodactyl.OperationKey); op.AsString() != "appapi.Users.Get" {
+						t.Errorf("unexpected operation attribute %q", op.AsString())
+					}
+					durations += int64(dp.Count)
+				}
+			case metricdata.Sum[int64]:
+				if m.Name != "pterodactyl.client.request.errors" {
+					continue
+				}
+				for _, dp := range data.DataPoints {
+					if et, _ := dp.Attributes.Value("error.type"); et.AsString() != "404" {
+						t.Errorf("expected error.type 404, got %q", et.AsString())
+					}
+					failures += dp.Value
+				}
+			}
+		}
+	}
+	if durations != 2 {
+		t.Errorf("expected 2 recorded durations, got %d", durations)
+	}
+	if failures != 1 {
+		t.Errorf("expected 1 recorded error, got %d", failures)
+	}
+}
@@ -0,0 +1,141 @@
+package ptdl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// Change is a field that differs between a local and a remote egg. Local or
+// Remote is nil when a docker image or variable only exists on one side.
+type Change struct {
+	// Field names the field using the export's names, e.g. "config.stop",
+	// "docker_images[Java 17]" or "variables[SERVER_JARFILE].rules".
+	Field  string
+	Local  any
+	Remote any
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: local %s, remote %s", c.Field, describe(c.Local), describe(c.Remote))
+}
+
+func describe(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "missing"
+	case string:
+		if strings.Contains(v, "\n") {
+			return fmt.Sprintf("%d lines", strings.Count(v, "\n")+1)
+		}
+		return fmt.Sprintf("%q", v)
+	case *api.EggVariable:
+		return fmt.Sprintf("%q", v.EnvVariable)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Diff returns the fields that differ between local, typically parsed from an
+// export file, and remote, typically fetched from the panel. Only fields that
+// an export carries are compared, and line endings in scripts are ignored.
+//
+// Fetch remote with api.EggIncludeVariables, or every local variable is
+// reported as missing. Including api.EggIncludeConfig and
+// api.EggIncludeScript compares the inherited configuration, which is what
+// the panel exports.
+func Diff(local, remote *api.Egg) []Change {
+	var changes []Change
+	add := func(field string, l, r any) {
+		changes = append(changes, Change{Field: field, Local: l, Remote: r})
+	}
+	compare := func(field string, l, r any) {
+		if !reflect.DeepEqual(l, r) {
+			add(field, l, r)
+		}
+	}
+
+	compare("name", local.Name, remote.Name)
+	compare("author", local.Author, remote.Author)
+	compare("description", local.Description, remote.Description)
+	compare("startup", normalize(local.Startup), normalize(remote.Startup))
+
+	for _, label := range sortedKeys(local.DockerImages) {
+		image, ok := remote.DockerImages[label]
+		if !ok {
+			add("docker_images["+label+"]", local.DockerImages[label], nil)
+		} else if image != local.DockerImages[label] {
+			add("docker_images["+label+"]", local.DockerImages[label], image)
+		}
+	}
+	for _, label := range sortedKeys(remote.DockerImages) {
+		if _, ok := local.DockerImages[label]; !ok {
+			add("docker_images["+label+"]", nil, remote.DockerImages[label])
+		}
+	}
+
+	lc, ls := effective(local)
+	rc, rs := effective(remote)
+	if len(lc.Files) > 0 || len(rc.Files) > 0 {
+		compare("config.files", lc.Files, rc.Files)
+	}
+	if !sameStrings(lc.Startup.Done, rc.Startup.Done) || !sameStrings(lc.Startup.UserInteraction, rc.Startup.UserInteraction) {
+		add("config.startup", lc.Startup, rc.Startup)
+	}
+	compare("config.logs", lc.Logs, rc.Logs)
+	compare("config.stop", lc.Stop, rc.Stop)
+	if !sameStrings(lc.FileDenylist, rc.FileDenylist) {
+		add("file_denylist", lc.FileDenylist, rc.FileDenylist)
+	}
+	compare("scripts.installation.script", normalize(ls.Install), normalize(rs.Install))
+	compare("scripts.installation.container", ls.Container, rs.Container)
+	compare("scripts.installation.entrypoint", ls.Entry, rs.Entry)
+
+	remoteVars := make(map[string]*api.EggVariable, len(remote.Variables))
+	for _, v := range remote.Variables {
+		remoteVars[v.EnvVariable] = v
+	}
+	localVars := make(map[string]bool, len(local.Variables))
+	for _, l := range local.Variables {
+		localVars[l.EnvVariable] = true
+		field := "variables[" + l.EnvVariable + "]"
+		r, ok := remoteVars[l.EnvVariable]
+		if !ok {
+			add(field, l, nil)
+			continue
+		}
+		compare(field+".name", l.Name, r.Name)
+		compare(field+".description", normalize(l.Description), normalize(r.Description))
+		compare(field+".default_value", l.DefaultValue, r.DefaultValue)
+		compare(field+".user_viewable", l.UserViewable, r.UserViewable)
+		compare(field+".user_editable", l.UserEditable, r.UserEditable)
+		compare(field+".rules", l.Rules, r.Rules)
+	}
+	for _, r := range remote.Variables {
+		if !localVars[r.EnvVariable] {
+			add("variables["+r.EnvVariable+"]", nil, r)
+		}
+	}
+	return changes
+}
+
+// normalize converts Windows line endings, which exports edited in the
+// panel's text areas often contain.
+func normalize(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+// sameStrings compares two lists, treating nil and empty as equal.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package ptdl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+func TestDiff(t *testing.T) {
+	local, err := Parse([]byte(paperV2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The panel returns the egg's own configuration in the attributes and
+	// the inherited one through the config include, which takes precedence.
+	remote := &api.Egg{
+		ID:           5,
+		Name:         "Paper",
+		Author:       "parker@pterodactyl.io",
+		Description:  "High performance Spigot fork.",
+		DockerImages: map[string]string{"Java 17": "ghcr.io/pterodactyl/yolks:java_17", "Java 11": "ghcr.io/pterodactyl/yolks:java_11"},
+		Startup:      local.Startup,
+		Config:       api.EggConfig{Stop: "end"},
+		Script:       api.EggScript{Install: "#!/bin/ash\ncd /mnt/server\necho done", Container: "ghcr.io/pterodactyl/installers:alpine", Entry: "ash"},
+		Variables: []*api.EggVariable{
+			{Name: "Server Jar File", Description: "The name of the server jarfile to run.", EnvVariable: "SERVER_JARFILE",
+				DefaultValue: "paper.jar", UserViewable: true, UserEditable: true, Rules: local.Variables[0].Rules},
+			{Name: "Build Number", EnvVariable: "BUILD_NUMBER", Rules: "required|string"},
+		},
+	}
+	cfg := local.Config
+	remote.ConfigDetails = &cfg
+
+	var got []string
+	for _, c := range Diff(local, remote) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		`docker_images[Java 21]: local "ghcr.io/pterodactyl/yolks:java_21", remote missing`,
+		`docker_images[Java 11]: local missing, remote "ghcr.io/pterodactyl/yolks:java_11"`,
+		`variables[SERVER_JARFILE].default_value: local "server.jar", remote "paper.jar"`,
+		`variables[MINECRAFT_VERSION]: local "MINECRAFT_VERSION", remote missing`,
+		`variables[BUILD_NUMBER]: local missing, remote "BUILD_NUMBER"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDiff_Config(t *testing.T) {
+	local := &api.Egg{Config: api.EggConfig{
+		Files:   map[string]api.EggConfigFile{"server.properties": {Parser: "properties", Find: map[string]any{"server-port": "25565"}}},
+		Startup: api.EggStartup{Done: []string{"Done"}},
+		Stop:    "stop",
+	}}
+	remote := &api.Egg{Config: api.EggConfig{
+		Files:        map[string]api.EggConfigFile{"server.properties": {Parser: "properties", Find: map[string]any{"server-port": "{{server.build.default.port}}"}}},
+		Startup:      api.EggStartup{Done: []string{"Done"}},
+		Stop:         "stop",
+		FileDenylist: []string{},
+	}}
+
+	changes := Diff(local, remote)
+	if len(changes) != 1 || changes[0].Field != "config.files" {
+		t.Errorf("expected only config.files to differ, got %v", changes)
+	}
+}
@@ -0,0 +1,344 @@
+// Package ptdl reads and writes eggs in the panel's export format (PTDL) so
+// that eggs kept in version control can be compared with, and converted to
+// and from, the api.Egg values returned by appapi.EggsService.
+//
+//	local, err := ptdl.Parse(data)
+//	if err != nil { … }
+//	remote, err := sdk.ApplicationAPI.Nests.Eggs(1).Get(ctx, 5,
+//		api.EggIncludeConfig, api.EggIncludeScript, api.EggIncludeVariables)
+//	if err != nil { … }
+//	for _, c := range ptdl.Diff(local, remote) {
+//		fmt.Println(c)
+//	}
+//
+// Both PTDL_v1 and PTDL_v2 files are parsed; Export always writes PTDL_v2.
+package ptdl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+const (
+	Version1 = "PTDL_v1"
+	Version2 = "PTDL_v2"
+)
+
+// comment is the header the panel writes into every export.
+const comment = "DO NOT EDIT: FILE GENERATED AUTOMATICALLY BY PTERODACTYL PANEL - PTERODACTYL.IO"
+
+// atom is the timestamp layout of exported_at.
+const atom = "2006-01-02T15:04:05-07:00"
+
+// now is replaced in tests.
+var now = time.Now
+
+type meta struct {
+	Version   string  `json:"version"`
+	UpdateURL *string `json:"update_url"`
+}
+
+// document is a PTDL_v2 file, with fields in the order the panel writes them.
+type document struct {
+	Comment      string            `json:"_comment"`
+	Meta         meta              `json:"meta"`
+	ExportedAt   string            `json:"exported_at"`
+	Name         string            `json:"name"`
+	Author       string            `json:"author"`
+	Description  string            `json:"description"`
+	Features     []string          `json:"features"`
+	DockerImages map[string]string `json:"docker_images"`
+	FileDenylist []string          `json:"file_denylist"`
+	Startup      string            `json:"startup"`
+	Config       config            `json:"config"`
+	Scripts      scripts           `json:"scripts"`
+	Variables    []variable        `json:"variables"`
+}
+
+// config holds the egg configuration as JSON-encoded strings, which is how
+// the panel stores and exports it.
+type config struct {
+	Files   string `json:"files"`
+	Startup string `json:"startup"`
+	Logs    string `json:"logs"`
+	Stop    string `json:"stop"`
+}
+
+type scripts struct {
+	Installation installation `json:"installation"`
+}
+
+type installation struct {
+	Script     string `json:"script"`
+	Container  string `json:"container"`
+	Entrypoint string `json:"entrypoint"`
+}
+
+type variable struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	EnvVariable  string `json:"env_variable"`
+	DefaultValue string `json:"default_value"`
+	UserViewable flag   `json:"user_viewable"`
+	UserEditable flag   `json:"user_editable"`
+	Rules        rules  `json:"rules"`
+	FieldType    string `json:"field_type"`
+}
+
+// flag is a boolean that older exports write as 0 or 1.
+type flag bool
+
+func (f *flag) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	switch s {
+	case "", "null":
+		*f = false
+		return nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	*f = flag(b)
+	return nil
+}
+
+// rules are the Laravel validation rules of a variable, written as a
+// pipe-separated string. Hand-written files sometimes use a list instead.
+type rules string
+
+func (r *rules) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*r = rules(strings.Join(list, "|"))
+		return nil
+	}
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid rules %s", data)
+	}
+	if s != nil {
+		*r = rules(*s)
+	}
+	return nil
+}
+
+// Parse decodes a PTDL_v1 or PTDL_v2 export into an egg. The returned egg has
+// no IDs or timestamps; its variables are set as if the egg had been fetched
+// with api.EggIncludeVariables.
+func Parse(data []byte) (*api.Egg, error) {
+	var raw struct {
+		document
+		Config json.RawMessage `json:"config"`
+		// PTDL_v1 fields, replaced by docker_images in PTDL_v2.
+		Image  string   `json:"image"`
+		Images []string `json:"images"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse egg: %w", err)
+	}
+	switch raw.Meta.Version {
+	case Version1:
+		// Eggs from before multiple images were supported list the images
+		// by themselves; the panel uses each image as its own label.
+		images := raw.Images
+		if len(images) == 0 && raw.Image != "" {
+			images = []string{raw.Image}
+		}
+		if len(raw.DockerImages) == 0 && len(images) > 0 {
+			raw.DockerImages = make(map[string]string, len(images))
+			for _, image := range images {
+				raw.DockerImages[image] = image
+			}
+		}
+	case Version2:
+	case "":
+		return nil, fmt.Errorf("failed to parse egg: missing meta.version")
+	default:
+		return nil, fmt.Errorf("failed to parse egg: unsupported version %q", raw.Meta.Version)
+	}
+
+	egg := &api.Egg{
+		Name:         raw.Name,
+		Author:       raw.Author,
+		Description:  raw.Description,
+		Features:     raw.Features,
+		DockerImages: raw.DockerImages,
+		Startup:      raw.Startup,
+		Script: api.EggScript{
+			Install:   raw.Scripts.Installation.Script,
+			Container: raw.Scripts.Installation.Container,
+			Entry:     raw.Scripts.Installation.Entrypoint,
+		},
+		Variables: make([]*api.EggVariable, len(raw.Variables)),
+	}
+	if raw.Meta.UpdateURL != nil {
+		egg.UpdateURL = *raw.Meta.UpdateURL
+	}
+	if len(raw.Config) > 0 {
+		if err := json.Unmarshal(raw.Config, &egg.Config); err != nil {
+			return nil, fmt.Errorf("failed to parse egg config: %w", err)
+		}
+	}
+	egg.Config.FileDenylist = raw.FileDenylist
+	for i, v := range raw.Variables {
+		egg.Variables[i] = &api.EggVariable{
+			Name:         v.Name,
+			Description:  v.Description,
+			EnvVariable:  v.EnvVariable,
+			DefaultValue: v.DefaultValue,
+			UserViewable: bool(v.UserViewable),
+			UserEditable: bool(v.UserEditable),
+			Rules:        string(v.Rules),
+		}
+	}
+	return egg, nil
+}
+
+// Export encodes an egg as a PTDL_v2 file, formatted the way the panel
+// formats its own exports. Docker images are written in label order since
+// api.Egg does not keep the panel's ordering.
+//
+// If the egg was fetched with api.EggIncludeConfig or api.EggIncludeScript,
+// the inherited configuration and script are exported, as the panel does.
+// Variables are only exported if they were included.
+func Export(egg *api.Egg) ([]byte, error) {
+	cfg, script := effective(egg)
+
+	files, err := encodeConfig(cfg.Files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode egg config files: %w", err)
+	}
+	startup, err := encodeConfig(cfg.Startup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode egg config startup: %w", err)
+	}
+	logs, err := encodeConfig(cfg.Logs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode egg config logs: %w", err)
+	}
+
+	doc := document{
+		Comment:      comment,
+		Meta:         meta{Version: Version2},
+		ExportedAt:   now().Format(atom),
+		Name:         egg.Name,
+		Author:       egg.Author,
+		Description:  egg.Description,
+		Features:     egg.Features,
+		DockerImages: egg.DockerImages,
+		FileDenylist: cfg.FileDenylist,
+		Startup:      egg.Startup,
+		Config:       config{Files: files, Startup: startup, Logs: logs, Stop: cfg.Stop},
+		Scripts: scripts{Installation: installation{
+			Script:     script.Install,
+			Container:  script.Container,
+			Entrypoint: script.Entry,
+		}},
+		Variables: make([]variable, len(egg.Variables)),
+	}
+	if egg.UpdateURL != "" {
+		doc.Meta.UpdateURL = &egg.UpdateURL
+	}
+	if doc.DockerImages == nil {
+		doc.DockerImages = map[string]string{}
+	}
+	if doc.FileDenylist == nil {
+		doc.FileDenylist = []string{}
+	}
+	for i, v := range egg.Variables {
+		doc.Variables[i] = variable{
+			Name:         v.Name,
+			Description:  v.Description,
+			EnvVariable:  v.EnvVariable,
+			DefaultValue: v.DefaultValue,
+			UserViewable: flag(v.UserViewable),
+			UserEditable: flag(v.UserEditable),
+			Rules:        rules(v.Rules),
+			FieldType:    "text",
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode egg: %w", err)
+	}
+	return phpEscape(bytes.TrimRight(buf.Bytes(), "\n")), nil
+}
+
+// effective returns the configuration and script the egg runs with, which
+// are the inherited ones when they were included.
+func effective(egg *api.Egg) (api.EggConfig, api.EggScript) {
+	cfg, script := egg.Config, egg.Script
+	if egg.ConfigDetails != nil {
+		cfg = *egg.ConfigDetails
+		if cfg.FileDenylist == nil {
+			cfg.FileDenylist = egg.Config.FileDenylist
+		}
+	}
+	if egg.ScriptDetails != nil {
+		script = *egg.ScriptDetails
+	}
+	return cfg, script
+}
+
+// encodeConfig encodes one of the configuration values as the indented JSON
+// string the panel stores, writing zero values as an empty object.
+func encodeConfig(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	switch string(data) {
+	case "null", "{}", `{"done":null}`:
+		return "{}", nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "    "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// phpEscape rewrites Go's JSON output to match PHP's json_encode, which
+// escapes forward slashes and non-ASCII characters. Both can only occur
+// inside strings, so the output can be rewritten byte by byte.
+func phpEscape(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for _, r := range string(data) {
+		switch {
+		case r == '/':
+			buf.WriteString(`\/`)
+		case r < 0x80:
+			buf.WriteByte(byte(r))
+		case r > 0xFFFF:
+			r1, r2 := utf16.EncodeRune(r)
+			fmt.Fprintf(&buf, `\u%04x\u%04x`, r1, r2)
+		default:
+			fmt.Fprintf(&buf, `\u%04x`, r)
+		}
+	}
+	return buf.Bytes()
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,256 @@
+package ptdl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+const paperV2 = `{
+    "_comment": "DO NOT EDIT: FILE GENERATED AUTOMATICALLY BY PTERODACTYL PANEL - PTERODACTYL.IO",
+    "meta": {
+        "version": "PTDL_v2",
+        "update_url": null
+    },
+    "exported_at": "2024-03-01T10:00:00+00:00",
+    "name": "Paper",
+    "author": "parker@pterodactyl.io",
+    "description": "High performance Spigot fork.",
+    "features": [
+        "eula",
+        "java_version"
+    ],
+    "docker_images": {
+        "Java 17": "ghcr.io\/pterodactyl\/yolks:java_17",
+        "Java 21": "ghcr.io\/pterodactyl\/yolks:java_21"
+    },
+    "file_denylist": [],
+    "startup": "java -Xms128M -Xmx{{SERVER_MEMORY}}M -jar {{SERVER_JARFILE}}",
+    "config": {
+        "files": "{\r\n    \"server.properties\": {\r\n        \"parser\": \"properties\",\r\n        \"find\": {\r\n            \"server-port\": \"{{server.build.default.port}}\"\r\n        }\r\n    }\r\n}",
+        "startup": "{\r\n    \"done\": \")! For help, type \"\r\n}",
+        "logs": "{}",
+        "stop": "stop"
+    },
+    "scripts": {
+        "installation": {
+            "script": "#!\/bin\/ash\r\ncd \/mnt\/server\r\necho done",
+            "container": "ghcr.io\/pterodactyl\/installers:alpine",
+            "entrypoint": "ash"
+        }
+    },
+    "variables": [
+        {
+            "name": "Server Jar File",
+            "description": "The name of the server jarfile to run.",
+            "env_variable": "SERVER_JARFILE",
+            "default_value": "server.jar",
+            "user_viewable": true,
+            "user_editable": true,
+            "rules": "required|regex:\/^([\\w\\d._-]+)(\\.jar)$\/",
+            "field_type": "text"
+        },
+        {
+            "name": "Minecraft Version",
+            "description": "The version of Minecraft to download.",
+            "env_variable": "MINECRAFT_VERSION",
+            "default_value": "latest",
+            "user_viewable": true,
+            "user_editable": false,
+            "rules": "nullable|string|max:20",
+            "field_type": "text"
+        }
+    ]
+}`
+
+const vanillaV1 = `{
+    "_comment": "DO NOT EDIT: FILE GENERATED AUTOMATICALLY BY PTERODACTYL PANEL - PTERODACTYL.IO",
+    "meta": {"version": "PTDL_v1"},
+    "exported_at": "2020-01-01T00:00:00+00:00",
+    "name": "Vanilla Minecraft",
+    "author": "support@pterodactyl.io",
+    "description": "Minecraft.",
+    "image": "quay.io\/pterodactyl\/core:java",
+    "startup": "java -jar {{SERVER_JARFILE}}",
+    "config": {
+        "files": "{}",
+        "startup": "{\"done\": \")! For help, type \", \"userInteraction\": []}",
+        "logs": "{\"custom\": false, \"location\": \"logs\/latest.log\"}",
+        "stop": "stop"
+    },
+    "scripts": {"installation": {"script": "echo hi", "container": "openjdk:8-jdk-slim", "entrypoint": "bash"}},
+    "variables": [
+        {
+            "name": "Server Jar File",
+            "description": "",
+            "env_variable": "SERVER_JARFILE",
+            "default_value": "server.jar",
+            "user_viewable": 1,
+            "user_editable": 0,
+            "rules": ["required", "string"]
+        }
+    ]
+}`
+
+func TestParse_V2(t *testing.T) {
+	egg, err := Parse([]byte(paperV2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if egg.Name != "Paper" || egg.Author != "parker@pterodactyl.io" {
+		t.Errorf("unexpected name or author: %q, %q", egg.Name, egg.Author)
+	}
+	if egg.DockerImages["Java 21"] != "ghcr.io/pterodactyl/yolks:java_21" {
+		t.Errorf("unexpected docker images: %v", egg.DockerImages)
+	}
+	if len(egg.Features) != 2 || egg.Features[0] != "eula" {
+		t.Errorf("unexpected features: %v", egg.Features)
+	}
+	if f := egg.Config.Files["server.properties"]; f.Parser != "properties" || f.Find["server-port"] != "{{server.build.default.port}}" {
+		t.Errorf("unexpected config files: %+v", egg.Config.Files)
+	}
+	if len(egg.Config.Startup.Done) != 1 || egg.Config.Startup.Done[0] != ")! For help, type " {
+		t.Errorf("unexpected startup detection: %+v", egg.Config.Startup)
+	}
+	if egg.Config.Stop != "stop" || egg.Script.Entry != "ash" || egg.Script.Container != "ghcr.io/pterodactyl/installers:alpine" {
+		t.Errorf("unexpected config or script: %+v, %+v", egg.Config, egg.Script)
+	}
+	if len(egg.Variables) != 2 {
+		t.Fatalf("expected 2 variables, got %d", len(egg.Variables))
+	}
+	if v := egg.Variables[0]; v.EnvVariable != "SERVER_JARFILE" || !v.Required() || v.Rules != `required|regex:/^([\w\d._-]+)(\.jar)$/` {
+		t.Errorf("unexpected variable: %+v", v)
+	}
+	if egg.Variables[1].UserEditable {
+		t.Error("expected MINECRAFT_VERSION not to be user editable")
+	}
+}
+
+func TestParse_V1(t *testing.T) {
+	egg, err := Parse([]byte(vanillaV1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(egg.DockerImages) != 1 || egg.DockerImages["quay.io/pterodactyl/core:java"] != "quay.io/pterodactyl/core:java" {
+		t.Errorf("expected the single image to be converted, got %v", egg.DockerImages)
+	}
+	if egg.Config.Logs.Location != "logs/latest.log" {
+		t.Errorf("unexpected logs: %+v", egg.Config.Logs)
+	}
+	v := egg.Variables[0]
+	if !v.UserViewable || v.UserEditable || v.Rules != "required|string" {
+		t.Errorf("unexpected variable: %+v", v)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"invalid json", `{`, "failed to parse egg"},
+		{"missing version", `{"name": "x"}`, "missing meta.version"},
+		{"unknown version", `{"meta": {"version": "PTDL_v9"}}`, `unsupported version "PTDL_v9"`},
+		{"invalid flag", `{"meta": {"version": "PTDL_v2"}, "variables": [{"user_viewable": "maybe"}]}`, "invalid boolean"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.data))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	egg := &api.Egg{
+		Name:         "Tiny",
+		Author:       "ops@example.com",
+		Description:  "Café <server>",
+		DockerImages: map[string]string{"Alpine": "alpine:3"},
+		Startup:      "./run.sh",
+		Config: api.EggConfig{
+			Startup: api.EggStartup{Done: []string{"ready"}},
+			Stop:    "^C",
+		},
+		Script:    api.EggScript{Install: "echo hi", Container: "alpine:3", Entry: "ash"},
+		Variables: []*api.EggVariable{{Name: "Port", EnvVariable: "PORT", DefaultValue: "80", UserViewable: true, Rules: "required|integer"}},
+	}
+	data, err := Export(egg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{
+    "_comment": "DO NOT EDIT: FILE GENERATED AUTOMATICALLY BY PTERODACTYL PANEL - PTERODACTYL.IO",
+    "meta": {
+        "version": "PTDL_v2",
+        "update_url": null
+    },
+    "exported_at": "2024-03-01T10:00:00+00:00",
+    "name": "Tiny",
+    "author": "ops@example.com",
+    "description": "Caf\u00e9 <server>",
+    "features": null,
+    "docker_images": {
+        "Alpine": "alpine:3"
+    },
+    "file_denylist": [],
+    "startup": ".\/run.sh",
+    "config": {
+        "files": "{}",
+        "startup": "{\n    \"done\": \"ready\"\n}",
+        "logs": "{}",
+        "stop": "^C"
+    },
+    "scripts": {
+        "installation": {
+            "script": "echo hi",
+            "container": "alpine:3",
+            "entrypoint": "ash"
+        }
+    },
+    "variables": [
+        {
+            "name": "Port",
+            "description": "",
+            "env_variable": "PORT",
+            "default_value": "80",
+            "user_viewable": true,
+            "user_editable": false,
+            "rules": "required|integer",
+            "field_type": "text"
+        }
+    ]
+}`
+	if string(data) != want {
+		t.Errorf("unexpected export:\n%s", data)
+	}
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	egg, err := Parse([]byte(paperV2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := Export(egg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, err := Parse(data)
+	if err != nil {
+		t.Fatalf("failed to parse the export: %v", err)
+	}
+	if changes := Diff(egg, again); len(changes) != 0 {
+		t.Errorf("expected no changes after a round trip, got %v", changes)
+	}
+	if len(again.Features) != 2 {
+		t.Errorf("expected features to survive a round trip, got %v", again.Features)
+	}
+}
@@ -0,0 +1,167 @@
+package pterodactyl
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is the panel's default per-key quota.
+const DefaultRequestsPerMinute = 240
+
+// RateLimiter is a client-side token bucket that every request through
+// Client.Do waits on. One limiter may be shared by several Clients that use
+// the same API key, so that a whole worker pool stays within the quota.
+//
+// The bucket calibrates itself from the panel's X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset response headers: the refill
+// rate follows the advertised limit, the bucket never holds more tokens
+// than the panel says remain, and an exhausted quota pauses all callers
+// until it resets.
+//
+// RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	paused time.Time // no token is handed out before this instant
+
+	requests int64
+	waits    int64
+	waited   time.Duration
+}
+
+// RateLimiterStats is a snapshot of a RateLimiter's counters.
+type RateLimiterStats struct {
+	// Requests is the number of requests that passed through the limiter.
+	Requests int64
+	// Waits is how many of them had to wait for a token.
+	Waits int64
+	// WaitTime is the total time spent waiting, including waits that were
+	// cut short because their context ended.
+	WaitTime time.Duration
+	// RequestsPerMinute is the currently calibrated refill rate.
+	RequestsPerMinute int
+}
+
+// NewRateLimiter returns a limiter allowing perMinute requests per minute
+// with bursts of up to burst requests. Non-positive values fall back to
+// DefaultRequestsPerMinute and a burst of 1.
+func NewRateLimiter(perMinute, burst int) *RateLimiter {
+	if perMinute <= 0 {
+		perMinute = DefaultRequestsPerMinute
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   float64(perMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimiter makes every request wait on l before it is sent.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) { c.limiter = l }
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	l.tokens--
+	l.requests++
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if l.paused.After(now) {
+		if p := l.paused.Sub(now); p > wait {
+			wait = p
+		}
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		l.waits++
+		l.waited += time.Since(now)
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++ // hand the reservation back
+		l.requests--
+		l.waited += time.Since(now)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill must be called with l.mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+}
+
+// Observe calibrates the limiter from a panel response's rate-limit headers.
+// Client.Do calls it for every response.
+func (l *RateLimiter) Observe(h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.refill(now)
+
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil && limit > 0 {
+		l.rate = float64(limit) / 60
+	}
+	if remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		if float64(remaining) < l.tokens {
+			l.tokens = float64(remaining)
+		}
+		if remaining == 0 {
+			if d, ok := retryAfter(h); ok {
+				if until := now.Add(d); until.After(l.paused) {
+					l.paused = until
+				}
+			}
+		}
+	}
+}
+
+// Burst returns the maximum number of requests the limiter lets through at
+// once.
+func (l *RateLimiter) Burst() int {
+	return int(l.burst)
+}
+
+// Stats returns a snapshot of the limiter's counters.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return RateLimiterStats{
+		Requests:          l.requests,
+		Waits:             l.waits,
+		WaitTime:          l.waited,
+		RequestsPerMinute: int(l.rate*60 + 0.5),
+	}
+}
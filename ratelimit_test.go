@@ -0,0 +1,112 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	// 600/min refills one token every 100ms.
+	l := pterodactyl.NewRateLimiter(600, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the limiter to throttle, finished in %s", elapsed)
+	}
+
+	stats := l.Stats()
+	if stats.Requests != 4 || stats.Waits != 2 {
+		t.Errorf("expected 4 requests and 2 waits, got %+v", stats)
+	}
+	if stats.WaitTime <= 0 {
+		t.Errorf("expected wait time to be recorded, got %s", stats.WaitTime)
+	}
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	t.Parallel()
+
+	// The second request would wait a minute for its token.
+	l := pterodactyl.NewRateLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected the wait to be cancelled")
+	}
+
+	stats := l.Stats()
+	if stats.Requests != 1 || stats.Waits != 0 {
+		t.Errorf("expected 1 request and no completed waits, got %+v", stats)
+	}
+	if stats.WaitTime < 20*time.Millisecond || stats.WaitTime > time.Second {
+		t.Errorf("expected the time actually waited, got %s", stats.WaitTime)
+	}
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("calibrates rate", func(t *testing.T) {
+		l := pterodactyl.NewRateLimiter(240, 1)
+		l.Observe(http.Header{"X-Ratelimit-Limit": []string{"720"}})
+		if rpm := l.Stats().RequestsPerMinute; rpm != 720 {
+			t.Errorf("expected 720 requests per minute, got %d", rpm)
+		}
+	})
+
+	t.Run("pauses when exhausted", func(t *testing.T) {
+		l := pterodactyl.NewRateLimiter(6000, 10)
+		l.Observe(http.Header{
+			"X-Ratelimit-Remaining": []string{"0"},
+			"Retry-After":           []string{"30"},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if err := l.Wait(ctx); err != context.DeadlineExceeded {
+			t.Errorf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	panel := &scriptedPanel{}
+	l := pterodactyl.NewRateLimiter(6000, 1)
+	c := newTestClient(t, panel, pterodactyl.WithRateLimiter(l))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := getUser(c); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hits := panel.hits(); hits != 10 {
+		t.Errorf("expected 10 requests, got %d", hits)
+	}
+	stats := l.Stats()
+	if stats.Requests != 10 || stats.Waits == 0 {
+		t.Errorf("expected all requests to pass through the limiter, got %+v", stats)
+	}
+}
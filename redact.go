@@ -0,0 +1,149 @@
+package pterodactyl
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces every secret removed by a Redactor.
+const Redacted = "[REDACTED]"
+
+// DefaultSensitiveKeys are the JSON fields and query parameters of the panel
+// API that carry secrets:
+//   - password, current_password, password_confirmation: account, user and
+//     database passwords (including the password relationship returned by
+//     ClientDatabaseCreateResponse)
+//   - secret, image_url_data, tokens: two-factor setup and recovery codes
+//   - code: the one-time TOTP code sent to enable or disable two-factor
+//     authentication (this also hides the exception code of logged error
+//     responses; their status and detail are kept)
+//   - secret_token, token: API key secrets, Wings websocket JWTs and the
+//     token query parameter of signed download/upload URLs
+//   - token_id: the Wings daemon token pair in NodeConfiguration
+var DefaultSensitiveKeys = []string{
+	"password",
+	"current_password",
+	"password_confirmation",
+	"secret",
+	"code",
+	"secret_token",
+	"token",
+	"tokens",
+	"token_id",
+	"image_url_data",
+}
+
+// DefaultSensitiveHeaders are the HTTP headers a Redactor always removes.
+var DefaultSensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Redactor strips secrets from headers, URLs and JSON bodies before they are
+// logged. The zero value is not usable; create one with NewRedactor.
+type Redactor struct {
+	keys    map[string]struct{}
+	headers map[string]struct{}
+}
+
+// NewRedactor returns a Redactor for DefaultSensitiveKeys and
+// DefaultSensitiveHeaders plus any extra JSON keys.
+func NewRedactor(extraKeys ...string) *Redactor {
+	r := &Redactor{
+		keys:    make(map[string]struct{}),
+		headers: make(map[string]struct{}),
+	}
+	for _, k := range DefaultSensitiveKeys {
+		r.keys[strings.ToLower(k)] = struct{}{}
+	}
+	for _, k := range extraKeys {
+		r.keys[strings.ToLower(k)] = struct{}{}
+	}
+	for _, h := range DefaultSensitiveHeaders {
+		r.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	return r
+}
+
+func (r *Redactor) sensitive(key string) bool {
+	_, ok := r.keys[strings.ToLower(key)]
+	return ok
+}
+
+// Header returns a copy of h with sensitive headers replaced.
+func (r *Redactor) Header(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if _, ok := r.headers[http.CanonicalHeaderKey(k)]; ok {
+			out[k] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+// URL returns u as a string with sensitive query parameters replaced.
+func (r *Redactor) URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	q := u.Query()
+	changed := false
+	for k := range q {
+		if r.sensitive(k) {
+			q[k] = []string{Redacted}
+			changed = true
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	c := *u
+	c.RawQuery = q.Encode()
+	return c.String()
+}
+
+// JSON returns body with the values of sensitive keys replaced, at any depth.
+// String values that are URLs have their query redacted as well. Bodies that
+// are not JSON are returned unchanged.
+func (r *Redactor) JSON(body []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(r.value(v)); err != nil {
+		return body
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+}
+
+func (r *Redactor) value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if r.sensitive(k) {
+				v[k] = Redacted
+				continue
+			}
+			v[k] = r.value(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.value(child)
+		}
+	case string:
+		if strings.Contains(v, "?") && strings.Contains(v, "://") {
+			if u, err := url.Parse(v); err == nil {
+				return r.URL(u)
+			}
+		}
+	}
+	return v
+}
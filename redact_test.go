@@ -0,0 +1,118 @@
+package pterodactyl_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl"
+)
+
+func TestRedactor_JSON(t *testing.T) {
+	t.Parallel()
+
+	r := pterodactyl.NewRedactor("remote")
+	testCases := []struct {
+		name      string
+		body      string
+		secrets   []string
+		preserved []string
+	}{
+		{
+			name:      "database password relationship",
+			body:      `{"object":"server_database","attributes":{"id":"bEY4yAD5","name":"s1_db","relationships":{"password":{"object":"database_password","attributes":{"password":"hunter2"}}}}}`,
+			secrets:   []string{"hunter2"},
+			preserved: []string{"s1_db"},
+		},
+		{
+			name:      "api key secret",
+			body:      `{"object":"api_key","attributes":{"identifier":"wWR8nQkl"},"meta":{"secret_token":"ptlc_secret"}}`,
+			secrets:   []string{"ptlc_secret"},
+			preserved: []string{"wWR8nQkl"},
+		},
+		{
+			name:    "two-factor setup",
+			body:    `{"data":{"image_url_data":"otpauth://totp/Pterodactyl?secret=ABC","secret":"ABC"}}`,
+			secrets: []string{"ABC", "otpauth"},
+		},
+		{
+			name:    "two-factor enable request",
+			body:    `{"code":"481516"}`,
+			secrets: []string{"481516"},
+		},
+		{
+			name:      "two-factor disable request",
+			body:      `{"password":"hunter2","code":"234200"}`,
+			secrets:   []string{"hunter2", "234200"},
+			preserved: []string{"password", "code"},
+		},
+		{
+			name:    "recovery tokens",
+			body:    `{"object":"recovery_tokens","attributes":{"tokens":["r1","r2"]}}`,
+			secrets: []string{"r1", "r2"},
+		},
+		{
+			name:      "signed url",
+			body:      `{"object":"signed_url","attributes":{"url":"https://wings.example.com/download/file?token=eyJhbGciOi&server=abc"}}`,
+			secrets:   []string{"eyJhbGciOi"},
+			preserved: []string{"https://wings.example.com/download/file?", "server=abc"},
+		},
+		{
+			name:      "password change request",
+			body:      `{"current_password":"old","password":"new","password_confirmation":"new"}`,
+			secrets:   []string{"old", "new"},
+			preserved: []string{"current_password"},
+		},
+		{
+			name:    "extra keys",
+			body:    `{"remote":"10.0.0.%"}`,
+			secrets: []string{"10.0.0"},
+		},
+		{
+			name:      "not json",
+			body:      `plain text`,
+			preserved: []string{"plain text"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			out := string(r.JSON([]byte(tc.body)))
+			for _, s := range tc.secrets {
+				if strings.Contains(out, s) {
+					t.Errorf("expected %q to be redacted from %s", s, out)
+				}
+			}
+			for _, s := range tc.preserved {
+				if !strings.Contains(out, s) {
+					t.Errorf("expected %q to be kept in %s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactor_HeaderAndURL(t *testing.T) {
+	t.Parallel()
+
+	r := pterodactyl.NewRedactor()
+	h := http.Header{"Authorization": {"Bearer ptla_secret"}, "Accept": {"application/json"}}
+	out := r.Header(h)
+	if out.Get("Authorization") != pterodactyl.Redacted {
+		t.Errorf("expected Authorization to be redacted, got %q", out.Get("Authorization"))
+	}
+	if out.Get("Accept") != "application/json" {
+		t.Errorf("expected Accept to be kept, got %q", out.Get("Accept"))
+	}
+	if h.Get("Authorization") != "Bearer ptla_secret" {
+		t.Error("expected the original header to be left untouched")
+	}
+
+	u, _ := url.Parse("https://wings.example.com/upload/file?token=eyJ&directory=/")
+	if got := r.URL(u); strings.Contains(got, "eyJ") || !strings.Contains(got, "directory=") {
+		t.Errorf("unexpected redacted URL %q", got)
+	}
+}
@@ -0,0 +1,182 @@
+package pterodactyl
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client.Do retries failed requests. It is enabled
+// with WithRetry; by default every request is attempted exactly once.
+//
+// A request is retried when:
+//   - the panel answered 429 Too Many Requests (any method – the request was
+//     rejected before it was processed), or
+//   - the method is idempotent (GET, HEAD, OPTIONS, PUT, DELETE) and the
+//     request failed at the transport level or a proxy answered 502/503/504.
+//
+// Requests whose body cannot be rewound (no http.Request.GetBody) are never
+// replayed.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Defaults to 3.
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the exponential back-off used when the
+	// response carries no rate-limit hint. Default to 500ms and 30s. A hint
+	// asking to wait longer than MaxBackoff is not honoured: the response is
+	// returned without retrying.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// WithRetry enables automatic retries with the given policy.
+//
+//	sdk, _ := pterodactyl.NewClient(baseURL, token, key,
+//	    pterodactyl.WithRetry(pterodactyl.RetryPolicy{MaxAttempts: 5}))
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		if p.MaxAttempts <= 0 {
+			p.MaxAttempts = 3
+		}
+		if p.MinBackoff <= 0 {
+			p.MinBackoff = 500 * time.Millisecond
+		}
+		if p.MaxBackoff <= 0 {
+			p.MaxBackoff = 30 * time.Second
+		}
+		c.retry = &p
+	}
+}
+
+// send performs req with hc, retrying according to c.retry, and records the
+// number of attempts made on call. Every attempt to the panel waits on
+// c.limiter, if one is configured; requests to Wings are not limited.
+func (c *Client) send(ctx context.Context, hc *http.Client, req *http.Request, call *Call) (*http.Response, error) {
+	limiter := c.limiter
+	if call.external {
+		limiter = nil
+	}
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		call.Attempts = attempt
+		res, err := hc.Do(req)
+		if limiter != nil && err == nil {
+			limiter.Observe(res.Header)
+		}
+		if c.retry == nil || attempt >= c.retry.MaxAttempts || !c.shouldRetry(ctx, req, res, err) {
+			return res, err
+		}
+
+		wait, ok := c.retry.delay(attempt, res)
+		if !ok {
+			return res, err
+		}
+		if res != nil {
+			// Drain so the connection can be reused.
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4<<10))
+			res.Body.Close()
+		}
+
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) shouldRetry(ctx context.Context, req *http.Request, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err == nil && res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// delay picks the wait before the next attempt, preferring the panel's own
+// hints over exponential back-off. It reports false when the hint exceeds
+// MaxBackoff, so that a misbehaving proxy or clock cannot stall a call for
+// hours.
+func (p *RetryPolicy) delay(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if d, ok := retryAfter(res.Header); ok {
+			return d, d <= p.MaxBackoff
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1)), true
+}
+
+// retryAfter reads Retry-After (seconds or HTTP date) or, when the quota is
+// exhausted, X-RateLimit-Reset (unix seconds).
+func retryAfter(h http.Header) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return nonNegative(time.Duration(secs) * time.Second), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(time.Until(t)), true
+		}
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return nonNegative(time.Until(time.Unix(reset, 0))), true
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
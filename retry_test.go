@@ -0,0 +1,219 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// scriptedPanel answers each request with the next status in statuses and
+// records the request bodies it saw.
+type scriptedPanel struct {
+	mu       sync.Mutex
+	statuses []int
+	headers  []http.Header
+	bodies   []string
+}
+
+func (p *scriptedPanel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	p.bodies = append(p.bodies, string(body))
+
+	i := len(p.bodies) - 1
+	status := http.StatusOK
+	if i < len(p.statuses) {
+		status = p.statuses[i]
+	}
+	if i < len(p.headers) {
+		for k, v := range p.headers[i] {
+			w.Header()[k] = v
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if status >= 400 {
+		_, _ = w.Write([]byte(`{"errors":[{"code":"HttpException","status":"` + strconv.Itoa(status) + `","detail":"try again"}]}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"object":"user","attributes":{"id":1,"username":"alex"}}`))
+}
+
+func (p *scriptedPanel) hits() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.bodies)
+}
+
+func newTestClient(t *testing.T, h http.Handler, opts ...pterodactyl.Option) *pterodactyl.Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c, err := pterodactyl.NewClient(srv.URL, "ptla_test", pterodactyl.ApplicationKey, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func fastRetry() pterodactyl.Option {
+	return pterodactyl.WithRetry(pterodactyl.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	})
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		statuses      []int
+		headers       []http.Header
+		opts          []pterodactyl.Option
+		call          func(c *pterodactyl.Client) error
+		expectedHits  int
+		expectedError bool
+	}{
+		{
+			name:         "no retry by default",
+			statuses:     []int{http.StatusServiceUnavailable},
+			call:         getUser,
+			expectedHits: 1, expectedError: true,
+		},
+		{
+			name:         "retries 503 on GET",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 3,
+		},
+		{
+			name:         "gives up after MaxAttempts",
+			statuses:     []int{503, 503, 503, 503},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 3, expectedError: true,
+		},
+		{
+			name:         "honors Retry-After",
+			statuses:     []int{http.StatusTooManyRequests},
+			headers:      []http.Header{{"Retry-After": []string{"0"}}},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 2,
+		},
+		{
+			name:     "honors X-RateLimit-Reset",
+			statuses: []int{http.StatusTooManyRequests},
+			headers: []http.Header{{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Unix(), 10)},
+			}},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 2,
+		},
+		{
+			name:         "gives up when Retry-After exceeds MaxBackoff",
+			statuses:     []int{http.StatusTooManyRequests},
+			headers:      []http.Header{{"Retry-After": []string{"86400"}}},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 1, expectedError: true,
+		},
+		{
+			name:     "gives up when X-RateLimit-Reset is too far away",
+			statuses: []int{http.StatusTooManyRequests},
+			headers: []http.Header{{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+			}},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 1, expectedError: true,
+		},
+		{
+			name:         "does not retry 503 on POST",
+			statuses:     []int{http.StatusServiceUnavailable},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         createUser,
+			expectedHits: 1, expectedError: true,
+		},
+		{
+			name:         "retries 429 on POST",
+			statuses:     []int{http.StatusTooManyRequests},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         createUser,
+			expectedHits: 2,
+		},
+		{
+			name:         "does not retry 404",
+			statuses:     []int{http.StatusNotFound},
+			opts:         []pterodactyl.Option{fastRetry()},
+			call:         getUser,
+			expectedHits: 1, expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			panel := &scriptedPanel{statuses: tc.statuses, headers: tc.headers}
+			c := newTestClient(t, panel, tc.opts...)
+
+			err := tc.call(c)
+			if tc.expectedError && err == nil {
+				t.Error("expected an error but got none")
+			}
+			if !tc.expectedError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+			if hits := panel.hits(); hits != tc.expectedHits {
+				t.Errorf("expected %d attempts, got %d", tc.expectedHits, hits)
+			}
+		})
+	}
+}
+
+func TestWithRetry_RewindsBody(t *testing.T) {
+	t.Parallel()
+
+	panel := &scriptedPanel{statuses: []int{http.StatusTooManyRequests}}
+	c := newTestClient(t, panel, fastRetry())
+
+	if err := createUser(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(panel.bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(panel.bodies))
+	}
+	if panel.bodies[0] == "" || panel.bodies[0] != panel.bodies[1] {
+		t.Errorf("expected identical bodies on replay, got %q and %q", panel.bodies[0], panel.bodies[1])
+	}
+}
+
+func getUser(c *pterodactyl.Client) error {
+	_, err := c.ApplicationAPI.Users.Get(context.Background(), 1)
+	return err
+}
+
+func createUser(c *pterodactyl.Client) error {
+	_, err := c.ApplicationAPI.Users.Create(context.Background(), api.UserCreateOptions{
+		Email:    "alex@example.com",
+		Username: "alex",
+	})
+	return err
+}
@@ -0,0 +1,169 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+func TestFiles_Walk(t *testing.T) {
+	t.Parallel()
+
+	_, files := newFakeFiles(t, map[string]string{
+		"/a/1.txt":        "1",
+		"/a/skip/2.txt":   "2",
+		"/b/3.txt":        "3",
+		"/world/region/x": "x",
+		"/z.txt":          "z",
+	})
+
+	var visited []string
+	err := files.Walk(context.Background(), "/", api.WalkOptions{Skip: []string{"world/region"}}, func(filePath string, file *api.FileObject) error {
+		visited = append(visited, filePath)
+		if file.Name == "skip" {
+			// A wrapped SkipDir skips the directory too.
+			return fmt.Errorf("skipping %s: %w", filePath, fs.SkipDir)
+		}
+		if file.Name == "3.txt" {
+			// Skips the rest of /b.
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"/a", "/a/1.txt", "/a/skip", "/b", "/b/3.txt", "/world", "/z.txt"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("expected %v, got %v", want, visited)
+	}
+
+	visited = nil
+	err = files.Walk(context.Background(), "/a", api.WalkOptions{MaxDepth: 1}, func(filePath string, file *api.FileObject) error {
+		visited = append(visited, filePath)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(visited, []string{"/a/1.txt", "/a/skip"}) {
+		t.Errorf("expected only the root entries, got %v, %v", visited, err)
+	}
+}
+
+func TestFiles_Find(t *testing.T) {
+	t.Parallel()
+
+	fake, files := newFakeFiles(t, map[string]string{
+		"/plugins/Essentials.jar":     "jar v1",
+		"/plugins/Vault.jar":          "jar",
+		"/plugins/Essentials/old.jar": "old jar",
+		"/plugins/Essentials/a.yml":   "a",
+		"/world/region/r.0.0.jar":     "not a plugin",
+	})
+	recent := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	fake.files["/plugins/Vault.jar"].mod = recent
+
+	find := func(options api.FindOptions) []string {
+		t.Helper()
+		found, err := files.Find(context.Background(), "/", options)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		paths := make([]string, len(found))
+		for i, f := range found {
+			paths[i] = f.Path
+		}
+		return paths
+	}
+
+	skipRegion := api.WalkOptions{Skip: []string{"region"}}
+	if got, want := find(api.FindOptions{WalkOptions: skipRegion, Name: "*.jar"}), []string{"/plugins/Essentials.jar", "/plugins/Essentials/old.jar", "/plugins/Vault.jar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := find(api.FindOptions{Name: "plugins/*.jar", MinSize: 4}), []string{"/plugins/Essentials.jar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := find(api.FindOptions{Name: "*.jar", ModifiedAfter: recent.Add(-time.Hour)}), []string{"/plugins/Vault.jar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := find(api.FindOptions{Name: "*.jar", ModifiedBefore: recent, MaxSize: 6, Concurrency: 1}), []string{"/plugins/Essentials.jar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := find(api.FindOptions{Name: "Essentials*", IncludeDirs: true}), []string{"/plugins/Essentials", "/plugins/Essentials.jar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, err := files.Find(context.Background(), "/", api.FindOptions{Name: "["}); err == nil {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+	if _, err := files.Find(context.Background(), "/missing", api.FindOptions{}); err == nil {
+		t.Error("expected a missing root to fail")
+	}
+}
+
+func TestFiles_Grep(t *testing.T) {
+	t.Parallel()
+
+	_, files := newFakeFiles(t, map[string]string{
+		"/server.properties":        "motd=hi\r\nrcon.password=hunter2\r\n",
+		"/plugins/Essentials/a.yml": "token: abc\nother: 1\ntoken: def",
+		"/world/level.dat":          "\x00token: binary",
+		"/world/region/r.0.0.mca":   "token: region",
+		"/logs/latest.log":          "token: " + string(make([]byte, 100)),
+	})
+
+	matches, err := files.Grep(context.Background(), "/", regexp.MustCompile(`token|password`), api.GrepOptions{
+		FindOptions: api.FindOptions{WalkOptions: api.WalkOptions{Skip: []string{"world/region"}}, Concurrency: 2},
+		MaxFileSize: 64,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make([]string, len(matches))
+	for i, m := range matches {
+		got[i] = m.String()
+	}
+	want := []string{
+		"/plugins/Essentials/a.yml:1:token: abc",
+		"/plugins/Essentials/a.yml:3:token: def",
+		"/server.properties:2:rcon.password=hunter2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestFiles_Grep_SkipsUnreadableFiles(t *testing.T) {
+	t.Parallel()
+
+	fake, files := newFakeFiles(t, map[string]string{
+		"/a.txt":       "token: a",
+		"/locked.txt":  "token: locked",
+		"/broken-link": "",
+		"/z.txt":       "token: z",
+	})
+	fake.files["/locked.txt"].unreadable = true
+	fake.files["/broken-link"].symlink = true
+
+	matches, err := files.Grep(context.Background(), "/", regexp.MustCompile(`token`), api.GrepOptions{})
+	var fileErrs *api.FileErrors
+	if !errors.As(err, &fileErrs) {
+		t.Fatalf("expected *api.FileErrors, got %v", err)
+	}
+	if len(fileErrs.Errors) != 1 || fileErrs.Errors["/locked.txt"] == nil {
+		t.Errorf("expected only /locked.txt to fail, got %v", fileErrs.Errors)
+	}
+	got := make([]string, len(matches))
+	for i, m := range matches {
+		got[i] = m.String()
+	}
+	want := []string{"/a.txt:1:token: a", "/z.txt:1:token: z"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
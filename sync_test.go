@@ -0,0 +1,214 @@
+package pterodactyl_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+)
+
+// writeLocal creates files below dir with a modification time in the past.
+func writeLocal(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	old := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	for name, data := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func changeStrings(changes []api.SyncChange) []string {
+	out := make([]string, len(changes))
+	for i, c := range changes {
+		out[i] = c.String()
+	}
+	return out
+}
+
+func TestFiles_SyncDir_Push(t *testing.T) {
+	t.Parallel()
+
+	fake, files := newFakeFiles(t, map[string]string{
+		"/plugins/Old.jar":        "old",
+		"/plugins/Essentials.jar": "v1",
+		"/plugins/latest.log":     "remote log",
+	})
+	local := t.TempDir()
+	writeLocal(t, local, map[string]string{
+		"Essentials.jar":        "v1",
+		"WorldEdit.jar":         "we",
+		"WorldEdit/config.yml":  "limit: 10",
+		"debug.log":             "local log",
+		"Essentials/config.yml": "motd: hi",
+	})
+	ctx := context.Background()
+	options := api.SyncOptions{Exclude: []string{"*.log"}, Delete: true}
+
+	dry := options
+	dry.DryRun = true
+	changes, err := files.SyncDir(ctx, local, "/plugins", dry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"delete Old.jar",
+		"mkdir Essentials",
+		"mkdir WorldEdit",
+		"copy Essentials/config.yml (8 bytes)",
+		"copy WorldEdit.jar (2 bytes)",
+		"copy WorldEdit/config.yml (9 bytes)",
+	}
+	if got := changeStrings(changes); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected changes\n%q\ngot\n%q", want, got)
+	}
+	if len(fake.calls) != 0 {
+		t.Fatalf("expected a dry run to change nothing, got %v", fake.calls)
+	}
+
+	if _, err := files.SyncDir(ctx, local, "/plugins", options); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantFiles := map[string]string{
+		"/plugins/Essentials.jar":        "v1",
+		"/plugins/WorldEdit.jar":         "we",
+		"/plugins/WorldEdit/config.yml":  "limit: 10",
+		"/plugins/Essentials/config.yml": "motd: hi",
+		"/plugins/latest.log":            "remote log",
+	}
+	if got := fake.contents(); !reflect.DeepEqual(got, wantFiles) {
+		t.Errorf("expected server files %v, got %v", wantFiles, got)
+	}
+
+	// Uploaded files are newer on the server, so nothing is copied again.
+	changes, err = files.SyncDir(ctx, local, "/plugins", options)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected nothing left to sync, got %v, %v", changes, err)
+	}
+
+	writeLocal(t, local, map[string]string{"Essentials.jar": "v2"})
+	changes, err = files.SyncDir(ctx, local, "/plugins", api.SyncOptions{Exclude: []string{"*.log"}, Checksum: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := changeStrings(changes); !reflect.DeepEqual(got, []string{"copy Essentials.jar (2 bytes)"}) {
+		t.Errorf("expected the changed content to be found by checksum, got %q", got)
+	}
+}
+
+func TestFiles_SyncDir_PushToNewDirectory(t *testing.T) {
+	t.Parallel()
+
+	fake, files := newFakeFiles(t, nil)
+	local := t.TempDir()
+	writeLocal(t, local, map[string]string{"server.properties": "motd=hi"})
+
+	changes, err := files.SyncDir(context.Background(), local, "/config/live", api.SyncOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := changeStrings(changes); !reflect.DeepEqual(got, []string{"mkdir .", "copy server.properties (7 bytes)"}) {
+		t.Errorf("unexpected changes %q", got)
+	}
+	if got := fake.contents()["/config/live/server.properties"]; got != "motd=hi" {
+		t.Errorf("expected the file to be uploaded, got %q", got)
+	}
+}
+
+func TestFiles_SyncDir_Pull(t *testing.T) {
+	t.Parallel()
+
+	_, files := newFakeFiles(t, map[string]string{
+		"/config/server.properties": "motd=hi",
+		"/config/bukkit/bukkit.yml": "settings: {}",
+	})
+	local := t.TempDir()
+	writeLocal(t, local, map[string]string{"stale.yml": "x", "server.properties": "motd=old"})
+	ctx := context.Background()
+	options := api.SyncOptions{Direction: api.SyncPull, Delete: true}
+
+	changes, err := files.SyncDir(ctx, local, "/config", options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"delete stale.yml",
+		"mkdir bukkit",
+		"copy bukkit/bukkit.yml (12 bytes)",
+		"copy server.properties (7 bytes)",
+	}
+	if got := changeStrings(changes); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected changes\n%q\ngot\n%q", want, got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(local, "bukkit", "bukkit.yml"))
+	if err != nil || string(data) != "settings: {}" {
+		t.Errorf("expected the file to be downloaded, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(local, "stale.yml")); !os.IsNotExist(err) {
+		t.Errorf("expected the stale file to be deleted, got %v", err)
+	}
+
+	// Pulled files take the server's modification time.
+	changes, err = files.SyncDir(ctx, local, "/config", options)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected nothing left to sync, got %v, %v", changes, err)
+	}
+}
+
+// slowUploads sends upload bodies in small chunks with a pause before each.
+type slowUploads struct{ delay time.Duration }
+
+func (s slowUploads) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/upload/file" {
+		req.Body = &slowBody{ReadCloser: req.Body, delay: s.delay}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+type slowBody struct {
+	io.ReadCloser
+	delay time.Duration
+}
+
+func (b *slowBody) Read(p []byte) (int, error) {
+	time.Sleep(b.delay)
+	if len(p) > 64 {
+		p = p[:64]
+	}
+	return b.ReadCloser.Read(p)
+}
+
+func TestFiles_SyncDir_PushOutlastsClientTimeout(t *testing.T) {
+	t.Parallel()
+
+	fake, files := newFakeFiles(t, nil,
+		pterodactyl.WithTransport(slowUploads{delay: 20 * time.Millisecond}),
+		pterodactyl.WithTimeout(50*time.Millisecond))
+	local := t.TempDir()
+	config := strings.Repeat("setting: value\n", 30)
+	writeLocal(t, local, map[string]string{"config.yml": config})
+
+	changes, err := files.SyncDir(context.Background(), local, "/", api.SyncOptions{})
+	if err != nil {
+		t.Fatalf("expected the push to outlast the client timeout, got %v", err)
+	}
+	if len(changes) != 1 || fake.contents()["/config.yml"] != config {
+		t.Errorf("expected config.yml to be uploaded, got %v", changes)
+	}
+}
@@ -0,0 +1,168 @@
+package pterodactyl_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidarkless/go-pterodactyl"
+	"github.com/davidarkless/go-pterodactyl/api"
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// fakeUploads accepts uploads through signed URLs, rejecting tokens listed in
+// expired.
+type fakeUploads struct {
+	expired map[string]bool
+
+	mu       sync.Mutex
+	issued   int
+	files    map[string][]byte
+	requests []*http.Request
+}
+
+func (f *fakeUploads) servers(t *testing.T) *httptest.Server {
+	wings := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r)
+		if f.expired[r.URL.Query().Get("token")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"The provided token is expired."}`))
+			return
+		}
+		mr, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			content, _ := io.ReadAll(part)
+			f.files[r.URL.Query().Get("directory")+"/"+part.FileName()] = content
+		}
+	}))
+	t.Cleanup(wings.Close)
+
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/client/servers/abc/files/upload" {
+			http.NotFound(w, r)
+			return
+		}
+		f.mu.Lock()
+		f.issued++
+		token := strconv.Itoa(f.issued)
+		f.mu.Unlock()
+		fmt.Fprintf(w, `{"object":"signed_url","attributes":{"url":"%s/upload/file?token=%s"}}`, wings.URL, token)
+	}))
+	t.Cleanup(panel.Close)
+	return panel
+}
+
+func TestFiles_UploadFiles(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeUploads{expired: map[string]bool{"1": true}, files: map[string][]byte{}}
+	panel := f.servers(t)
+	c, err := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	world := testContent(300_000)
+	var sent int64
+	err = c.ClientAPI.Servers("abc").Files().UploadFiles(context.Background(), "/world", []api.UploadFile{
+		{Name: "level.dat", Content: bytes.NewReader(world)},
+		{Name: "session.lock", Content: bytes.NewReader([]byte("lock"))},
+	}, api.UploadOptions{
+		MaxSize: 1 << 20,
+		Progress: func(name string, uploaded, total int64) {
+			if name == "level.dat" {
+				sent = uploaded
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(f.files["/world/level.dat"], world) || string(f.files["/world/session.lock"]) != "lock" {
+		t.Errorf("expected both files to be uploaded intact, got %d files", len(f.files))
+	}
+	if sent != int64(len(world)) {
+		t.Errorf("expected progress to reach %d, got %d", len(world), sent)
+	}
+	if f.issued != 2 {
+		t.Errorf("expected one refresh after the expired URL, got %d URLs", f.issued)
+	}
+	for _, r := range f.requests {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("the panel key was sent to Wings")
+		}
+	}
+}
+
+func TestFiles_Upload_ExpiredURLNotRetriedForStreams(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeUploads{expired: map[string]bool{"1": true}, files: map[string][]byte{}}
+	panel := f.servers(t)
+	c, _ := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey)
+
+	err := c.ClientAPI.Servers("abc").Files().Upload(context.Background(), "/", "stream.log", io.MultiReader(bytes.NewReader([]byte("data"))))
+	if !pterrors.IsUnauthorized(err) {
+		t.Fatalf("expected the rejection to be returned, got %v", err)
+	}
+	if f.issued != 1 {
+		t.Errorf("expected a stream that cannot be rewound not to be retried, got %d URLs", f.issued)
+	}
+}
+
+// slowReader returns chunks of data with a pause before each.
+type slowReader struct {
+	chunks int
+	delay  time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.chunks == 0 {
+		return 0, io.EOF
+	}
+	r.chunks--
+	time.Sleep(r.delay)
+	return copy(p, "chunk"), nil
+}
+
+func TestFiles_Upload_OutlastsClientTimeout(t *testing.T) {
+	t.Parallel()
+
+	f := &fakeUploads{files: map[string][]byte{}}
+	panel := f.servers(t)
+	c, err := pterodactyl.NewClient(panel.URL, "ptlc_secret", pterodactyl.ClientKey, pterodactyl.WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = c.ClientAPI.Servers("abc").Files().Upload(context.Background(), "/", "slow.log", &slowReader{chunks: 6, delay: 30 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("expected the upload to outlast the client timeout, got %v", err)
+	}
+	if got := string(f.files["//slow.log"]); got != strings.Repeat("chunk", 6) {
+		t.Errorf("unexpected upload %q", got)
+	}
+}
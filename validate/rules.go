@@ -0,0 +1,292 @@
+package validate
+
+import (
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// Rule is a single Laravel rule with its parameters, e.g. max:20 or
+// in:vanilla,paper.
+type Rule struct {
+	Name   string
+	Params []string
+
+	re *regexp.Regexp
+}
+
+// Rules is a parsed rule string such as "required|string|max:20".
+type Rules []Rule
+
+// ParseRules parses a pipe-separated Laravel rule string. Unlike Laravel, a
+// regex rule may contain pipes as long as the pattern is properly delimited.
+// Rules other than the ones listed in the package documentation are kept but
+// never fail.
+func ParseRules(s string) (Rules, error) {
+	var rules Rules
+	for s != "" {
+		var part string
+		if strings.HasPrefix(s, "regex:") || strings.HasPrefix(s, "not_regex:") {
+			end, err := regexEnd(s)
+			if err != nil {
+				return nil, err
+			}
+			part, s = s[:end], strings.TrimPrefix(s[end:], "|")
+		} else if i := strings.IndexByte(s, '|'); i >= 0 {
+			part, s = s[:i], s[i+1:]
+		} else {
+			part, s = s, ""
+		}
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		rule, err := parseRule(part)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// regexEnd returns the length of the regex rule at the start of s: the
+// pattern up to its closing delimiter, followed by any flags.
+func regexEnd(s string) (int, error) {
+	start := strings.IndexByte(s, ':') + 1
+	if start >= len(s) {
+		return 0, fmt.Errorf("invalid rule %q: missing pattern", s)
+	}
+	delim := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case delim:
+			i++
+			for i < len(s) && isLetter(s[i]) {
+				i++
+			}
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid rule %q: unterminated pattern", s)
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+func parseRule(s string) (Rule, error) {
+	name, params, hasParams := strings.Cut(s, ":")
+	rule := Rule{Name: strings.ToLower(strings.TrimSpace(name))}
+
+	switch rule.Name {
+	case "regex", "not_regex":
+		re, err := compilePattern(params)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid rule %q: %w", s, err)
+		}
+		rule.Params, rule.re = []string{params}, re
+		return rule, nil
+	}
+	if hasParams {
+		rule.Params = strings.Split(params, ",")
+		for i, p := range rule.Params {
+			rule.Params[i] = strings.TrimSpace(p)
+		}
+	}
+
+	switch rule.Name {
+	case "min", "max":
+		if len(rule.Params) != 1 {
+			return Rule{}, fmt.Errorf("invalid rule %q: expected one parameter", s)
+		}
+	case "between":
+		if len(rule.Params) != 2 {
+			return Rule{}, fmt.Errorf("invalid rule %q: expected two parameters", s)
+		}
+	default:
+		return rule, nil
+	}
+	for _, p := range rule.Params {
+		if _, ok := new(big.Float).SetString(p); !ok {
+			return Rule{}, fmt.Errorf("invalid rule %q: %q is not a number", s, p)
+		}
+	}
+	return rule, nil
+}
+
+// compilePattern converts a PCRE pattern with delimiters and flags, as used
+// by PHP, into a Go regular expression.
+func compilePattern(p string) (*regexp.Regexp, error) {
+	if len(p) < 2 {
+		return nil, fmt.Errorf("missing delimiters")
+	}
+	delim := p[0]
+	end := strings.LastIndexByte(p, delim)
+	if end == 0 {
+		return nil, fmt.Errorf("missing closing delimiter")
+	}
+	pattern, flags := p[1:end], p[end+1:]
+
+	var goFlags string
+	for _, f := range flags {
+		switch f {
+		case 'i', 'm', 's':
+			goFlags += string(f)
+		case 'u', 'D':
+			// Go patterns are always UTF-8 and $ only matches at the end
+			// outside multi-line mode.
+		default:
+			return nil, fmt.Errorf("unsupported flag %q", f)
+		}
+	}
+	if goFlags != "" {
+		pattern = "(?" + goFlags + ")" + pattern
+	}
+	// PCRE allows escaping the delimiter, which is not an escape in RE2.
+	pattern = strings.ReplaceAll(pattern, `\`+string(delim), string(delim))
+	return regexp.Compile(pattern)
+}
+
+// Has reports whether the rules include the named rule.
+func (r Rules) Has(name string) bool {
+	for _, rule := range r {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Check validates value the way the panel does. Values are trimmed and an
+// empty value is treated as missing, as the panel converts it to null: it
+// fails required, passes everything when nullable is present, and is
+// otherwise checked like null, so rules such as string fail. A failed
+// required rule is the only error reported, as in Laravel.
+//
+// The attribute is only used in the error details.
+func (r Rules) Check(attribute, value string) []pterrors.FieldError {
+	value = strings.TrimSpace(value)
+	missing := value == ""
+	if missing {
+		if r.Has("required") {
+			return []pterrors.FieldError{fail("required", "The %s field is required.", attribute)}
+		}
+		if r.Has("nullable") {
+			return nil
+		}
+	}
+
+	numeric := r.Has("numeric") || r.Has("integer")
+	var failures []pterrors.FieldError
+	for _, rule := range r {
+		if failure, ok := rule.check(attribute, value, missing, numeric); !ok {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+var (
+	integerPattern   = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)$`)
+	numericPattern   = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+	alphaDashPattern = regexp.MustCompile(`^[\pL\pM\pN_-]+$`)
+)
+
+func (rule Rule) check(attribute, value string, missing, numeric bool) (pterrors.FieldError, bool) {
+	switch rule.Name {
+	case "string":
+		if missing {
+			return fail(rule.Name, "The %s must be a string.", attribute), false
+		}
+	case "integer":
+		if !integerPattern.MatchString(value) {
+			return fail(rule.Name, "The %s must be an integer.", attribute), false
+		}
+	case "numeric":
+		if !numericPattern.MatchString(value) {
+			return fail(rule.Name, "The %s must be a number.", attribute), false
+		}
+	case "boolean":
+		if value != "0" && value != "1" {
+			return fail(rule.Name, "The %s field must be true or false.", attribute), false
+		}
+	case "min", "max", "between":
+		return rule.checkSize(attribute, value, numeric)
+	case "in":
+		// Laravel reads the options as CSV, so they may be quoted.
+		for _, p := range rule.Params {
+			if !missing && value == strings.Trim(p, `"`) {
+				return pterrors.FieldError{}, true
+			}
+		}
+		return fail(rule.Name, "The selected %s is invalid.", attribute), false
+	case "regex", "not_regex":
+		if missing || rule.re.MatchString(value) != (rule.Name == "regex") {
+			return fail(rule.Name, "The %s format is invalid.", attribute), false
+		}
+	case "alpha_dash":
+		if !alphaDashPattern.MatchString(value) {
+			return fail(rule.Name, "The %s must only contain letters, numbers, dashes and underscores.", attribute), false
+		}
+	case "url":
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" || strings.ContainsAny(value, " \t") {
+			return fail(rule.Name, "The %s must be a valid URL.", attribute), false
+		}
+	}
+	return pterrors.FieldError{}, true
+}
+
+// checkSize applies min, max and between. Like Laravel, the value's size is
+// the number itself when a numeric or integer rule is present and the value
+// is numeric, and its length in characters otherwise.
+func (rule Rule) checkSize(attribute, value string, numeric bool) (pterrors.FieldError, bool) {
+	size, unit := new(big.Float).SetInt64(int64(utf8.RuneCountInString(value))), " characters"
+	if numeric && numericPattern.MatchString(value) {
+		size, _ = new(big.Float).SetString(value)
+		unit = ""
+	}
+	params := make([]*big.Float, len(rule.Params))
+	for i, p := range rule.Params {
+		params[i], _ = new(big.Float).SetString(p)
+	}
+
+	switch rule.Name {
+	case "min":
+		if size.Cmp(params[0]) < 0 {
+			return fail(rule.Name, "The %s must be at least %s%s.", attribute, rule.Params[0], unit), false
+		}
+	case "max":
+		if size.Cmp(params[0]) > 0 {
+			return fail(rule.Name, "The %s must not be greater than %s%s.", attribute, rule.Params[0], unit), false
+		}
+	case "between":
+		if size.Cmp(params[0]) < 0 || size.Cmp(params[1]) > 0 {
+			return fail(rule.Name, "The %s must be between %s and %s%s.", attribute, rule.Params[0], rule.Params[1], unit), false
+		}
+	}
+	return pterrors.FieldError{}, true
+}
+
+func fail(rule, format string, args ...any) pterrors.FieldError {
+	return pterrors.FieldError{Rule: rule, Detail: fmt.Sprintf(format, args...)}
+}
+
+// String returns the rules in Laravel's syntax.
+func (r Rules) String() string {
+	parts := make([]string, len(r))
+	for i, rule := range r {
+		parts[i] = rule.Name
+		if len(rule.Params) > 0 {
+			parts[i] += ":" + strings.Join(rule.Params, ",")
+		}
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		rules string
+		want  string
+	}{
+		{"required|string|max:20", "required|string|max:20"},
+		{"nullable|in:vanilla,paper, spigot", "nullable|in:vanilla,paper,spigot"},
+		{`required|regex:/^(latest|snapshot|[0-9.]+)$/i|max:10`, `required|regex:/^(latest|snapshot|[0-9.]+)$/i|max:10`},
+		{`Required| between:1,100 |`, "required|between:1,100"},
+	}
+	for _, tc := range testCases {
+		rules, err := ParseRules(tc.rules)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.rules, err)
+			continue
+		}
+		if got := rules.String(); got != tc.want {
+			t.Errorf("%q: expected %q, got %q", tc.rules, tc.want, got)
+		}
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, rules := range []string{
+		"max",
+		"max:ten",
+		"between:1",
+		"regex:/unterminated",
+		"regex:/[/",
+		"regex:/a/x",
+	} {
+		if _, err := ParseRules(rules); err == nil {
+			t.Errorf("%q: expected an error", rules)
+		}
+	}
+}
+
+func TestRules_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		rules string
+		value string
+		fails []string
+	}{
+		{"required|string|max:20", "", []string{"required"}},
+		{"required|string|max:20", "   ", []string{"required"}},
+		{"required|string|max:5", "abcdef", []string{"max"}},
+		{"required|string|max:5", "héllo", nil},
+		{"nullable|string|max:5", "", nil},
+		{"string|max:5", "", []string{"string"}},
+		{"required|integer|between:1,100", "50", nil},
+		{"required|integer|between:1,100", "150", []string{"between"}},
+		{"required|integer|between:1,100", "007", []string{"integer"}},
+		{"required|integer|between:1,100", "1.5", []string{"integer"}},
+		{"required|numeric|min:0.5", "0.25", []string{"min"}},
+		{"required|numeric|min:0.5", "1e3", nil},
+		{"required|numeric", "12abc", []string{"numeric"}},
+		{"required|boolean", "1", nil},
+		{"required|boolean", "true", []string{"boolean"}},
+		{"required|in:vanilla,paper", "paper", nil},
+		{"required|in:vanilla,paper", "forge", []string{"in"}},
+		{`required|regex:/^(latest|[0-9.]+)$/`, "1.20.4", nil},
+		{`required|regex:/^(latest|[0-9.]+)$/`, "snapshot", []string{"regex"}},
+		{`required|regex:/^LATEST$/i`, "latest", nil},
+		{`nullable|not_regex:/\s/`, "has space", []string{"not_regex"}},
+		{"required|alpha_dash", "my-world_2", nil},
+		{"required|alpha_dash", "my world", []string{"alpha_dash"}},
+		{"nullable|url", "https://example.com/pack.zip", nil},
+		{"nullable|url", "example.com/pack.zip", []string{"url"}},
+		{"required|string|sometimes|alpha_num", "!!", nil},
+	}
+	for _, tc := range testCases {
+		rules, err := ParseRules(tc.rules)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.rules, err)
+		}
+		var got []string
+		for _, f := range rules.Check("Value", tc.value) {
+			got = append(got, f.Rule)
+		}
+		if !reflect.DeepEqual(got, tc.fails) {
+			t.Errorf("%q with %q: expected failures %v, got %v", tc.rules, tc.value, tc.fails, got)
+		}
+	}
+}
+
+func TestRules_Check_Details(t *testing.T) {
+	t.Parallel()
+
+	rules, _ := ParseRules("required|string|min:3|max:5")
+	failures := rules.Check("Level Name", "ab")
+	if len(failures) != 1 || failures[0].Detail != "The Level Name must be at least 3 characters." {
+		t.Errorf("unexpected failures: %+v", failures)
+	}
+
+	rules, _ = ParseRules("required|integer|max:64")
+	failures = rules.Check("Max Players", "100")
+	if len(failures) != 1 || !strings.HasSuffix(failures[0].Detail, "greater than 64.") {
+		t.Errorf("unexpected failures: %+v", failures)
+	}
+}
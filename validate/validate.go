@@ -0,0 +1,79 @@
+// Package validate checks startup variable values against the Laravel rule
+// strings eggs attach to their variables, so that bad values are caught
+// before the panel rejects them with a 422.
+//
+//	if err := validate.Variable(variable, "paper"); err != nil {
+//		var verr *pterrors.ValidationError
+//		if errors.As(err, &verr) { … }
+//	}
+//
+// The rules understood are required, nullable, string, integer, numeric,
+// boolean, min, max, between, in, regex, not_regex, alpha_dash and url. Other
+// rules are left for the panel to check.
+//
+// Failures are reported as an *errors.ValidationError keyed by
+// "environment.<ENV_VARIABLE>", the field names the panel uses, so local and
+// remote failures can be handled the same way.
+package validate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+// Field returns the validation field name the panel uses for a variable.
+func Field(envVariable string) string {
+	return "environment." + envVariable
+}
+
+// Variable checks a new value for a client API startup variable, as sent by
+// StartupService.UpdateVariable. It returns an *errors.ValidationError if the
+// value fails the variable's rules, or an error if the rules cannot be
+// parsed.
+func Variable(v *api.StartupVariable, value string) error {
+	rules, err := ParseRules(v.Rules)
+	if err != nil {
+		return fmt.Errorf("failed to parse rules of %s: %w", v.EnvVariable, err)
+	}
+	return result(map[string][]pterrors.FieldError{
+		Field(v.EnvVariable): rules.Check(v.Name, value),
+	})
+}
+
+// Environment checks the environment of a server against its egg's
+// variables, as the panel does for ServerCreateOptions.Environment and
+// ServerUpdateStartupOptions.Environment. Variables missing from env are
+// checked as empty values; entries of env without a variable are ignored.
+func Environment(variables []*api.EggVariable, env map[string]string) error {
+	fields := make(map[string][]pterrors.FieldError)
+	for _, v := range variables {
+		rules, err := ParseRules(v.Rules)
+		if err != nil {
+			return fmt.Errorf("failed to parse rules of %s: %w", v.EnvVariable, err)
+		}
+		fields[Field(v.EnvVariable)] = rules.Check(v.Name, env[v.EnvVariable])
+	}
+	return result(fields)
+}
+
+// result returns a ValidationError holding the non-empty failures, or nil.
+func result(fields map[string][]pterrors.FieldError) error {
+	verr := &pterrors.ValidationError{Fields: make(map[string][]pterrors.FieldError)}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(fields[name]) > 0 {
+			verr.Fields[name] = fields[name]
+		}
+	}
+	if len(verr.Fields) == 0 {
+		return nil
+	}
+	return verr
+}
@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/davidarkless/go-pterodactyl/api"
+	pterrors "github.com/davidarkless/go-pterodactyl/errors"
+)
+
+func TestVariable(t *testing.T) {
+	t.Parallel()
+
+	v := &api.StartupVariable{Name: "Server Type", EnvVariable: "SERVER_TYPE", Rules: "required|string|in:vanilla,paper"}
+	if err := Variable(v, "paper"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := Variable(v, "forge")
+	var verr *pterrors.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if !errors.Is(err, pterrors.ErrValidation) || !pterrors.IsValidation(err) {
+		t.Error("expected the error to match ErrValidation")
+	}
+	if rules := verr.Rules("environment.SERVER_TYPE"); len(rules) != 1 || rules[0] != "in" {
+		t.Errorf("expected the in rule to fail, got %v", rules)
+	}
+	if want := "pterodactyl: validation failed: environment.SERVER_TYPE: The selected Server Type is invalid."; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+
+	v.Rules = "required|regex:/unterminated"
+	if err := Variable(v, "x"); err == nil || pterrors.IsValidation(err) {
+		t.Errorf("expected a rule parsing error, got %v", err)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	t.Parallel()
+
+	variables := []*api.EggVariable{
+		{Name: "Server Jar File", EnvVariable: "SERVER_JARFILE", Rules: `required|regex:/^([\w\d._-]+)(\.jar)$/`},
+		{Name: "Version", EnvVariable: "VERSION", Rules: "nullable|string|max:20"},
+		{Name: "Max Players", EnvVariable: "MAX_PLAYERS", Rules: "required|integer|between:1,500"},
+	}
+
+	err := Environment(variables, map[string]string{"SERVER_JARFILE": "server.jar", "MAX_PLAYERS": "20", "EXTRA": "x"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = Environment(variables, map[string]string{"SERVER_JARFILE": "server.zip"})
+	var verr *pterrors.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if len(verr.Fields) != 2 {
+		t.Errorf("expected two failing fields, got %v", verr.Fields)
+	}
+	if rules := verr.Rules("environment.SERVER_JARFILE"); len(rules) != 1 || rules[0] != "regex" {
+		t.Errorf("expected the regex rule to fail, got %v", rules)
+	}
+	if rules := verr.Rules("environment.MAX_PLAYERS"); len(rules) != 1 || rules[0] != "required" {
+		t.Errorf("expected the required rule to fail, got %v", rules)
+	}
+}